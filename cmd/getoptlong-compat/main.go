@@ -0,0 +1,105 @@
+/*
+	@file      cmd/getoptlong-compat/main.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BChristieDev/getopt_long.go/pkg/getoptlong"
+)
+
+func load(path string) (*getoptlong.Command, error) {
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return getoptlong.UnmarshalSchema(data)
+}
+
+func main() {
+	progname := filepath.Base(os.Args[0])
+	shortopts := "q"
+	longopts := []getoptlong.Option{
+		{Name: "quiet", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'q', Description: "only set the exit status"},
+	}
+	operands := []string{"old.json", "new.json"}
+	quiet := false
+
+	getoptlong.OptBuiltins = getoptlong.BuiltinHelp
+
+	for {
+		opt := getoptlong.Parse(len(os.Args), os.Args, shortopts, longopts, nil)
+
+		if opt == -1 {
+			break
+		}
+
+		switch opt {
+		case getoptlong.HelpRequested:
+			getoptlong.PrintUsage(os.Stdout, progname, shortopts, longopts, operands...)
+			getoptlong.PrintHelp(os.Stdout, shortopts, longopts)
+			os.Exit(0)
+		case 'q':
+			quiet = true
+		default:
+			getoptlong.PrintUsage(os.Stderr, progname, shortopts, longopts, operands...)
+			os.Exit(2)
+		}
+	}
+
+	if len(os.Args)-getoptlong.OptInd != 2 {
+		getoptlong.PrintUsage(os.Stderr, progname, shortopts, longopts, operands...)
+		os.Exit(2)
+	}
+
+	var cmds [2]*getoptlong.Command
+
+	for i, path := range os.Args[getoptlong.OptInd:] {
+		cmd, err := load(path)
+
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s: %v\n", progname, path, err)
+			os.Exit(2)
+		}
+
+		cmds[i] = cmd
+	}
+
+	var breaking, additive []getoptlong.Change
+
+	for _, change := range getoptlong.CheckCompat(cmds[0], cmds[1]) {
+		if change.Breaking {
+			breaking = append(breaking, change)
+		} else {
+			additive = append(additive, change)
+		}
+	}
+
+	if !quiet {
+		for _, section := range []struct {
+			heading string
+			changes []getoptlong.Change
+		}{{"Breaking changes:", breaking}, {"Additive changes:", additive}} {
+			if len(section.changes) == 0 {
+				continue
+			}
+
+			fmt.Println(section.heading)
+
+			for _, change := range section.changes {
+				fmt.Printf("  %s\n", change)
+			}
+		}
+	}
+
+	if len(breaking) > 0 {
+		os.Exit(1)
+	}
+}
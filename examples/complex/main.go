@@ -13,24 +13,13 @@ import (
 )
 
 func main() {
-	frob_state := struct {
-		unset int
-		off   int
-		on    int
-	}{
-		-1,
-		0,
-		1,
-	}
-
-	frob_flag := frob_state.unset
+	frob_flag := getoptlong.BoolUnset
 
 	longopts := []getoptlong.Option{
 		{Name: "foo", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'a'},
 		{Name: "bar", HasArg: getoptlong.OptionalArgument, Flag: nil, Val: 'b'},
 		{Name: "baz", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'c'},
-		{Name: "on", HasArg: getoptlong.NoArgument, Flag: &frob_flag, Val: frob_state.on},
-		{Name: "off", HasArg: getoptlong.NoArgument, Flag: &frob_flag, Val: frob_state.off},
+		{Name: "frob", HasArg: getoptlong.BooleanArgument, Flag: &frob_flag, Val: 0},
 		{Name: "silent", HasArg: getoptlong.NoArgument, Flag: nil, Val: 's'},
 	}
 
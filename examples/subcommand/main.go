@@ -0,0 +1,50 @@
+/*
+	@file      examples/subcommand/main.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/BChristieDev/getopt_long.go/pkg/getoptlong"
+)
+
+func main() {
+	verbose := false
+
+	root := &getoptlong.Command{
+		Name:      "tool",
+		Summary:   "Manage remotes",
+		Shortopts: "v",
+		Longopts: []getoptlong.Option{
+			{Name: "verbose", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'v', Description: "be verbose"},
+		},
+		OnOption: func(opt int, option *getoptlong.Option) { verbose = true },
+		Commands: []*getoptlong.Command{
+			{
+				Name:    "add",
+				Summary: "Add a remote",
+				Longopts: []getoptlong.Option{
+					{Name: "url", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 0, Required: true, Description: "URL of the remote"},
+				},
+				Operands: []string{"name"},
+				OnOption: func(opt int, option *getoptlong.Option) {
+					fmt.Printf("option '%s' has argument '%s'\n", option.Name, getoptlong.OptArg)
+				},
+				Run: func(operands []string) int {
+					fmt.Printf("adding '%s' (verbose: %t)\n", strings.Join(operands, " "), verbose)
+
+					return 0
+				},
+			},
+		},
+	}
+
+	getoptlong.OptBuiltins = getoptlong.BuiltinHelp
+
+	os.Exit(getoptlong.Execute(root, os.Args))
+}
@@ -0,0 +1,145 @@
+/*
+	@file      pkg/getoptlong/alias.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+/*
+Origins of the elements of argv, e.g. "alias 'co'"; an element without one was typed by the user.
+It is only used for the argv it was assigned along with, e.g. not for the next one parsed.
+*/
+var OptOrigin []string
+
+/* The argv OptOrigin was assigned along with. */
+var originArgv []string
+
+/*
+Reads the aliases of the lines "alias.NAME = BODY" of the file at path, ignoring blank lines, lines
+starting with '#' or ';', and keys of other sections, e.g. "color.ui = auto".
+*/
+func LoadAliases(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	aliases := map[string]string{}
+	scanner := bufio.NewScanner(file)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+
+		if text == "" || text[0] == '#' || text[0] == ';' {
+			continue
+		}
+
+		key, body, ok := strings.Cut(text, "=")
+
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected 'alias.NAME = BODY'", path, line)
+		}
+
+		name, ok := strings.CutPrefix(strings.TrimSpace(key), "alias.")
+
+		if !ok {
+			continue
+		}
+
+		if name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("%s:%d: invalid alias name '%s'", path, line, name)
+		}
+
+		aliases[name] = strings.TrimSpace(body)
+	}
+
+	return aliases, scanner.Err()
+}
+
+/* Assigns origins to OptOrigin for argv, and returns argv. */
+func withOrigins(argv []string, origins []string) []string {
+	OptOrigin = origins
+	originArgv = argv
+
+	return argv
+}
+
+/* Returns the origin of argv[index], or "" if it was typed by the user or OptOrigin is not of argv. */
+func origin(argv []string, index int) string {
+	if len(argv) == 0 || len(originArgv) != len(argv) || &originArgv[0] != &argv[0] {
+		return ""
+	}
+
+	if len(OptOrigin) != len(argv) || index < 0 || index >= len(OptOrigin) {
+		return ""
+	}
+
+	return OptOrigin[index]
+}
+
+/*
+Replaces argv[index] with the words of the body of the alias it names, split like a shell splits
+them, as long as the first word names another alias, and assigns the origins of the result to
+OptOrigin. An error is returned if an alias expands to itself, or its body cannot be split.
+*/
+func ExpandAlias(argv []string, index int, aliases map[string]string) ([]string, error) {
+	var expanded []string
+	var chain []string
+	name := argv[index]
+	words := []string{name}
+
+	for {
+		body, ok := aliases[words[0]]
+
+		if !ok {
+			break
+		}
+
+		if slices.Contains(chain, words[0]) {
+			return nil, fmt.Errorf("alias '%s' is recursive: %s", name, strings.Join(append(chain, words[0]), " -> "))
+		}
+
+		chain = append(chain, words[0])
+		bodyWords, err := Split(body, nil)
+
+		if err != nil {
+			return nil, fmt.Errorf("alias '%s': %v", words[0], err)
+		}
+
+		words = append(bodyWords, words[1:]...)
+
+		if len(words) == 0 {
+			break
+		}
+	}
+
+	origins := make([]string, 0, len(argv)+len(words))
+
+	for i := range index {
+		origins = append(origins, origin(argv, i))
+	}
+
+	for range words {
+		origins = append(origins, fmt.Sprintf("alias '%s'", name))
+	}
+
+	for i := index + 1; i < len(argv); i++ {
+		origins = append(origins, origin(argv, i))
+	}
+
+	expanded = append(expanded, argv[:index]...)
+	expanded = append(expanded, words...)
+	expanded = append(expanded, argv[index+1:]...)
+	return withOrigins(expanded, origins), nil
+}
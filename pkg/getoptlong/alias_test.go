@@ -0,0 +1,203 @@
+/*
+	@file      pkg/getoptlong/alias_test.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong_test
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/BChristieDev/getopt_long.go/pkg/getoptlong"
+)
+
+func TestLoadAliases(t *testing.T) {
+	t.Run("Aliases", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config")
+		config := "# aliases\n" +
+			"alias.co = checkout --quiet\n" +
+			"\n" +
+			"; other sections are ignored\n" +
+			"color.ui = auto\n" +
+			"alias.msg=commit -m 'fix typo'\n"
+		expected := map[string]string{"co": "checkout --quiet", "msg": "commit -m 'fix typo'"}
+
+		os.WriteFile(path, []byte(config), 0o644)
+
+		if aliases, err := getoptlong.LoadAliases(path); err != nil || !maps.Equal(aliases, expected) {
+			t.Errorf("aliases are '%v' (%v). Expected '%v'.\n", aliases, err, expected)
+		}
+	})
+
+	t.Run("Malformed line", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config")
+		expected := path + ":2: expected 'alias.NAME = BODY'"
+
+		os.WriteFile(path, []byte("alias.co = checkout\nalias.st\n"), 0o644)
+
+		if _, err := getoptlong.LoadAliases(path); err == nil || err.Error() != expected {
+			t.Errorf("error is '%v'. Expected '%s'.\n", err, expected)
+		}
+	})
+}
+
+func TestExpandAlias(t *testing.T) {
+	aliases := map[string]string{
+		"co":    "checkout --quiet",
+		"msg":   `commit -m "it's \"done\"" -m 'a  b' c\ d`,
+		"up":    "co --track",
+		"loop":  "again",
+		"again": "loop -v",
+		"open":  "commit 'oops",
+	}
+
+	t.Run("Expansion", func(t *testing.T) {
+		argv := []string{"tool", "-v", "co", "main"}
+		expected := []string{"tool", "-v", "checkout", "--quiet", "main"}
+		origins := []string{"", "", "alias 'co'", "alias 'co'", ""}
+
+		t.Cleanup(func() { cleanup(t) })
+
+		if actual, err := getoptlong.ExpandAlias(argv, 2, aliases); err != nil || !slices.Equal(actual, expected) {
+			t.Errorf("argv is '%v' (%v). Expected '%v'.\n", actual, err, expected)
+		}
+
+		if !slices.Equal(getoptlong.OptOrigin, origins) {
+			t.Errorf("OptOrigin is '%q'. Expected '%q'.\n", getoptlong.OptOrigin, origins)
+		}
+	})
+
+	t.Run("Quoting", func(t *testing.T) {
+		expected := []string{"tool", "commit", "-m", `it's "done"`, "-m", "a  b", "c d"}
+
+		t.Cleanup(func() { cleanup(t) })
+
+		if actual, err := getoptlong.ExpandAlias([]string{"tool", "msg"}, 1, aliases); err != nil || !slices.Equal(actual, expected) {
+			t.Errorf("argv is '%q' (%v). Expected '%q'.\n", actual, err, expected)
+		}
+	})
+
+	t.Run("Nested alias", func(t *testing.T) {
+		expected := []string{"tool", "checkout", "--quiet", "--track", "dev"}
+
+		t.Cleanup(func() { cleanup(t) })
+
+		if actual, err := getoptlong.ExpandAlias([]string{"tool", "up", "dev"}, 1, aliases); err != nil || !slices.Equal(actual, expected) {
+			t.Errorf("argv is '%v' (%v). Expected '%v'.\n", actual, err, expected)
+		}
+	})
+
+	t.Run("Recursive alias", func(t *testing.T) {
+		expected := "alias 'loop' is recursive: loop -> again -> loop"
+
+		t.Cleanup(func() { cleanup(t) })
+
+		if _, err := getoptlong.ExpandAlias([]string{"tool", "loop"}, 1, aliases); err == nil || err.Error() != expected {
+			t.Errorf("error is '%v'. Expected '%s'.\n", err, expected)
+		}
+	})
+
+	t.Run("Unterminated quote", func(t *testing.T) {
+		expected := "alias 'open': unterminated single quote at offset 7"
+
+		t.Cleanup(func() { cleanup(t) })
+
+		if _, err := getoptlong.ExpandAlias([]string{"tool", "open"}, 1, aliases); err == nil || err.Error() != expected {
+			t.Errorf("error is '%v'. Expected '%s'.\n", err, expected)
+		}
+	})
+
+	t.Run("Recursive after options", func(t *testing.T) {
+		root := &getoptlong.Command{
+			Name:      "tool",
+			Shortopts: "v",
+			Aliases:   map[string]string{"x": "-v x"},
+			Commands:  []*getoptlong.Command{{Name: "run", Run: func(operands []string) int { return 0 }}},
+		}
+		expected := "tool: alias 'x' is recursive"
+
+		t.Cleanup(func() { cleanup(t) })
+
+		getoptlong.OptErr = 0
+
+		if status := getoptlong.Execute(root, []string{"tool", "x"}); status != 2 {
+			t.Errorf("status is '%d'. Expected '2'.\n", status)
+		}
+
+		if getoptlong.LastError == nil || getoptlong.LastError.Msg != expected {
+			t.Errorf("LastError is '%v'. Expected '%s'.\n", getoptlong.LastError, expected)
+		}
+	})
+
+	t.Run("Errors point back to the alias", func(t *testing.T) {
+		root := &getoptlong.Command{
+			Name:    "tool",
+			Aliases: map[string]string{"co": "checkout --quiett"},
+			Commands: []*getoptlong.Command{
+				{
+					Name:     "checkout",
+					Longopts: []getoptlong.Option{{Name: "quiet", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'q'}},
+					Run:      func(operands []string) int { return 0 },
+				},
+			},
+		}
+		expected := "tool: unrecognized option '--quiett' (from alias 'co')\nDid you mean '--quiet'?"
+
+		t.Cleanup(func() { cleanup(t) })
+
+		getoptlong.OptErr = 0
+
+		if status := getoptlong.Execute(root, []string{"tool", "co", "main"}); status != 2 {
+			t.Errorf("status is '%d'. Expected '2'.\n", status)
+		}
+
+		if getoptlong.LastError == nil || getoptlong.LastError.Error() != expected || getoptlong.LastError.Index != 2 {
+			t.Errorf("LastError is '%+v'. Expected '%s' at index 2.\n", getoptlong.LastError, expected)
+		}
+	})
+
+	t.Run("Origins do not outlive the argv they are of", func(t *testing.T) {
+		root := &getoptlong.Command{
+			Name:     "tool",
+			Longopts: []getoptlong.Option{{Name: "dir", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'd'}},
+			Aliases:  map[string]string{"x": "run"},
+			Commands: []*getoptlong.Command{{Name: "run", Run: func(operands []string) int { return 0 }}},
+		}
+		expected := "tool: option '--dir' requires an argument"
+
+		t.Cleanup(func() { cleanup(t) })
+
+		getoptlong.OptErr = 0
+
+		if status := getoptlong.Execute(root, []string{"tool", "x"}); status != 0 {
+			t.Errorf("status is '%d'. Expected '0'.\n", status)
+		}
+
+		if status := getoptlong.Execute(root, []string{"tool", "--dir"}); status != 2 {
+			t.Errorf("status is '%d'. Expected '2'.\n", status)
+		}
+
+		if getoptlong.LastError == nil || getoptlong.LastError.Error() != expected {
+			t.Errorf("LastError is '%v'. Expected '%s'.\n", getoptlong.LastError, expected)
+		}
+
+		args := []string{"tool", "--dir"}
+		getoptlong.OptInd = 0
+		getoptlong.OptOrigin = []string{"", "alias 'x'", ""}
+
+		if opt := getoptlong.Parse(len(args), args, "", root.Longopts, nil); opt != ':' {
+			t.Errorf("opt is '%c'. Expected ':'.\n", opt)
+		}
+
+		if getoptlong.LastError == nil || getoptlong.LastError.Error() != expected {
+			t.Errorf("LastError is '%v'. Expected '%s'.\n", getoptlong.LastError, expected)
+		}
+
+		for getoptlong.Parse(len(args), args, "", root.Longopts, nil) != -1 {
+		}
+	})
+}
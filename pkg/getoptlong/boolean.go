@@ -0,0 +1,46 @@
+/*
+	@file      pkg/getoptlong/boolean.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+const (
+	/* Initial value of a BooleanArgument option's Flag to tell it was not given. */
+	BoolUnset = -1
+	/* Assigned to a BooleanArgument option's Flag when it is negated. */
+	BoolFalse = 0
+	/* Assigned to a BooleanArgument option's Flag when it is given. */
+	BoolTrue = 1
+)
+
+var boolChoices = []string{"yes", "no", "true", "false", "1", "0"}
+
+func parseBool(arg string, empty bool) (bool, bool) {
+	switch arg {
+	case "":
+		return empty, true
+	case "yes", "true", "1":
+		return true, true
+	case "no", "false", "0":
+		return false, true
+	}
+
+	return false, false
+}
+
+func formatBool(value bool) string {
+	if value {
+		return "true"
+	}
+
+	return "false"
+}
+
+func boolFlag(arg string) int {
+	if arg == formatBool(true) {
+		return BoolTrue
+	}
+
+	return BoolFalse
+}
@@ -0,0 +1,202 @@
+/*
+	@file      pkg/getoptlong/boolean_test.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong_test
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/BChristieDev/getopt_long.go/pkg/getoptlong"
+)
+
+func TestBooleanArgument(t *testing.T) {
+	t.Run("Unset", func(t *testing.T) {
+		args := []string{"", "foo"}
+		frob := getoptlong.BoolUnset
+		longopts := []getoptlong.Option{
+			{Name: "frob", HasArg: getoptlong.BooleanArgument, Flag: &frob, Val: 0},
+		}
+
+		t.Cleanup(func() { cleanup(t) })
+
+		for getoptlong.Parse(len(args), args, "", longopts, nil) != -1 {
+		}
+
+		if frob != getoptlong.BoolUnset {
+			t.Errorf("flag 'frob' is '%d'. Expected '%d'.\n", frob, getoptlong.BoolUnset)
+		}
+	})
+
+	t.Run("Negated", func(t *testing.T) {
+		args := []string{"", "--frob", "--no-frob", "foo"}
+		frob := getoptlong.BoolUnset
+		longopts := []getoptlong.Option{
+			{Name: "frob", HasArg: getoptlong.BooleanArgument, Flag: &frob, Val: 0},
+		}
+		var opt int
+		expected := []int{getoptlong.BoolTrue, getoptlong.BoolFalse}
+
+		t.Cleanup(func() { cleanup(t) })
+
+		for i := 0; ; i++ {
+			opt = getoptlong.Parse(len(args), args, "", longopts, nil)
+
+			if opt == -1 {
+				break
+			}
+
+			if opt != 0 {
+				t.Errorf("opt is '%d'. Expected '0'.\n", opt)
+			}
+
+			if frob != expected[i] {
+				t.Errorf("flag 'frob' is '%d'. Expected '%d'.\n", frob, expected[i])
+			}
+		}
+
+		if args[getoptlong.OptInd] != "foo" {
+			t.Errorf("positional argument is '%s'. Expected 'foo'.\n", args[getoptlong.OptInd])
+		}
+	})
+
+	t.Run("Custom prefix", func(t *testing.T) {
+		args := []string{"", "--without-frob"}
+		longopts := []getoptlong.Option{
+			{Name: "frob", HasArg: getoptlong.BooleanArgument, Flag: nil, Val: 'f'},
+		}
+		var opt int
+
+		t.Cleanup(func() { cleanup(t) })
+
+		getoptlong.OptNoPrefix = "without-"
+
+		for {
+			opt = getoptlong.Parse(len(args), args, "", longopts, nil)
+
+			if opt == -1 {
+				break
+			}
+
+			if opt != 'f' {
+				t.Errorf("opt is '%c'. Expected 'f'.\n", opt)
+			}
+
+			if getoptlong.OptArg != "false" {
+				t.Errorf("optarg is '%s'. Expected 'false'.\n", getoptlong.OptArg)
+			}
+		}
+	})
+
+	t.Run("Argument", func(t *testing.T) {
+		args := []string{"", "--frob=no", "--frob=1", "-f", "foo"}
+		longopts := []getoptlong.Option{
+			{Name: "frob", HasArg: getoptlong.BooleanArgument, Flag: nil, Val: 'f'},
+		}
+		var opt int
+		expected := []string{"false", "true", "true"}
+
+		t.Cleanup(func() { cleanup(t) })
+
+		for i := 0; ; i++ {
+			opt = getoptlong.Parse(len(args), args, "f", longopts, nil)
+
+			if opt == -1 {
+				break
+			}
+
+			if getoptlong.OptArg != expected[i] {
+				t.Errorf("optarg is '%s'. Expected '%s'.\n", getoptlong.OptArg, expected[i])
+			}
+		}
+
+		if getoptlong.Value("--frob") != "true" {
+			t.Errorf("value is '%s'. Expected 'true'.\n", getoptlong.Value("--frob"))
+		}
+
+		if args[getoptlong.OptInd] != "foo" {
+			t.Errorf("positional argument is '%s'. Expected 'foo'.\n", args[getoptlong.OptInd])
+		}
+	})
+
+	t.Run("Invalid argument", func(t *testing.T) {
+		args := []string{"getoptlong_test.go", "--frob=maybe"}
+		r, w, _ := os.Pipe()
+		oldStderr := os.Stderr
+		longopts := []getoptlong.Option{
+			{Name: "frob", HasArg: getoptlong.BooleanArgument, Flag: nil, Val: 'f'},
+		}
+		var opt int
+
+		t.Cleanup(func() { cleanup(t) })
+
+		os.Stderr = w
+		opt = getoptlong.Parse(len(args), args, "", longopts, nil)
+		w.Close()
+		stderr, _ := io.ReadAll(r)
+		os.Stderr = oldStderr
+
+		if opt != '?' {
+			t.Errorf("opt is '%c'. Expected '?'.\n", opt)
+		}
+
+		expected := "getoptlong_test.go: invalid argument 'maybe' for '--frob'\nValid arguments are:\n" +
+			"  - 'yes'\n  - 'no'\n  - 'true'\n  - 'false'\n  - '1'\n  - '0'\n"
+
+		if string(stderr) != expected {
+			t.Errorf("stderr is '%s'. Expected '%s'.\n", stderr, expected)
+		}
+	})
+
+	t.Run("Negated with argument", func(t *testing.T) {
+		args := []string{"getoptlong_test.go", "--no-frob=yes"}
+		r, w, _ := os.Pipe()
+		oldStderr := os.Stderr
+		longopts := []getoptlong.Option{
+			{Name: "frob", HasArg: getoptlong.BooleanArgument, Flag: nil, Val: 'f'},
+		}
+		var opt int
+
+		t.Cleanup(func() { cleanup(t) })
+
+		os.Stderr = w
+		opt = getoptlong.Parse(len(args), args, "", longopts, nil)
+		w.Close()
+		stderr, _ := io.ReadAll(r)
+		os.Stderr = oldStderr
+
+		if opt != '?' {
+			t.Errorf("opt is '%c'. Expected '?'.\n", opt)
+		}
+
+		if string(stderr) != "getoptlong_test.go: option '--no-frob' doesn't allow an argument\n" {
+			t.Errorf("stderr is '%s'. Expected 'getoptlong_test.go: option '--no-frob' doesn't allow an argument'.\n", stderr)
+		}
+	})
+
+	t.Run("Prefix alone", func(t *testing.T) {
+		args := []string{"getoptlong_test.go", "--no-"}
+		longopts := []getoptlong.Option{
+			{Name: "", HasArg: getoptlong.BooleanArgument, Flag: nil, Val: 'v'},
+		}
+		expected := "getoptlong_test.go: unrecognized option '--no-'"
+
+		t.Cleanup(func() { cleanup(t) })
+
+		getoptlong.OptErr = 0
+
+		if opt := getoptlong.Parse(len(args), args, "v", longopts, nil); opt != '?' {
+			t.Errorf("opt is '%c'. Expected '?'.\n", opt)
+		}
+
+		if getoptlong.LastError == nil || getoptlong.LastError.Error() != expected {
+			t.Errorf("LastError is '%v'. Expected '%s'.\n", getoptlong.LastError, expected)
+		}
+
+		for getoptlong.Parse(len(args), args, "v", longopts, nil) != -1 {
+		}
+	})
+}
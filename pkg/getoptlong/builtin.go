@@ -0,0 +1,93 @@
+/*
+	@file      pkg/getoptlong/builtin.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	/* Registers -h and --help, getoptlong.Parse returns HelpRequested when either is given. */
+	BuiltinHelp = 1 << iota
+	/* Registers --version, getoptlong.Parse returns VersionRequested when it is given. */
+	BuiltinVersion
+)
+
+const (
+	/* Returned by getoptlong.Parse for -h or --help when OptBuiltins includes BuiltinHelp. */
+	HelpRequested = -2
+	/* Returned by getoptlong.Parse for --version when OptBuiltins includes BuiltinVersion. */
+	VersionRequested = -3
+)
+
+var (
+	/* Built-in options registered in addition to shortopts and longopts, e.g. BuiltinHelp|BuiltinVersion; default 0. */
+	OptBuiltins = 0
+	stopped     = false
+)
+
+var builtinOptions = []struct {
+	builtin int
+	short   string
+	option  Option
+}{
+	{BuiltinHelp, "h", Option{Name: "help", HasArg: NoArgument, Flag: nil, Val: 'h', Description: "display this help and exit"}},
+	{BuiltinVersion, "", Option{Name: "version", HasArg: NoArgument, Flag: nil, Val: VersionRequested, Description: "output version information and exit"}},
+}
+
+/*
+Appends the options of OptBuiltins to shortopts and longopts, panicking if one of them is already
+defined, like the flag package does for a flag defined twice.
+*/
+func withBuiltins(shortopts string, longopts []Option) (string, []Option) {
+	if OptBuiltins == 0 {
+		return shortopts, longopts
+	}
+
+	longopts = longopts[:len(longopts):len(longopts)]
+
+	for _, builtin := range builtinOptions {
+		if OptBuiltins&builtin.builtin == 0 {
+			continue
+		}
+
+		for _, longopt := range longopts {
+			if longopt.Name == builtin.option.Name || (builtin.short != "" && longopt.Flag == nil && longopt.Val == int(builtin.short[0])) {
+				panic(fmt.Sprintf("getoptlong: built-in option '--%s' conflicts with option '%s'", builtin.option.Name, optionKey([]Option{longopt}, 0)))
+			}
+		}
+
+		if builtin.short != "" && strings.Contains(shortopts, builtin.short) {
+			panic(fmt.Sprintf("getoptlong: built-in option '-%s' conflicts with shortopts '%s'", builtin.short, shortopts))
+		}
+
+		shortopts += builtin.short
+		longopts = append(longopts, builtin.option)
+	}
+
+	return shortopts, longopts
+}
+
+/* Maps the result of a built-in option to its signal, ending the parse without checking the options given. */
+func builtinResult(opt int) int {
+	switch {
+	case opt == 'h' && OptBuiltins&BuiltinHelp != 0:
+		opt = HelpRequested
+	case opt == VersionRequested && OptBuiltins&BuiltinVersion != 0:
+	default:
+		return opt
+	}
+
+	if nextchar != 0 {
+		OptInd++
+		nextchar = 0
+	}
+
+	stopped = true
+
+	return opt
+}
@@ -0,0 +1,133 @@
+/*
+	@file      pkg/getoptlong/builtin_test.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong_test
+
+import (
+	"testing"
+
+	"github.com/BChristieDev/getopt_long.go/pkg/getoptlong"
+)
+
+func TestBuiltins(t *testing.T) {
+	t.Run("Help stops parsing", func(t *testing.T) {
+		args := []string{"prog", "-a", "--help", "--bogus", "-z"}
+		longopts := []getoptlong.Option{
+			{Name: "output", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'o', Required: true},
+		}
+		expected := []int{'a', getoptlong.HelpRequested, -1}
+
+		t.Cleanup(func() { cleanup(t) })
+
+		getoptlong.OptBuiltins = getoptlong.BuiltinHelp | getoptlong.BuiltinVersion
+
+		for i := range expected {
+			if opt := getoptlong.Parse(len(args), args, "ao:", longopts, nil); opt != expected[i] {
+				t.Errorf("opt is '%d'. Expected '%d'.\n", opt, expected[i])
+			}
+		}
+
+		if getoptlong.LastError != nil {
+			t.Errorf("LastError is '%v'. Expected 'nil'.\n", getoptlong.LastError)
+		}
+	})
+
+	t.Run("Short help in a cluster", func(t *testing.T) {
+		args := []string{"prog", "-ahz", "file"}
+
+		t.Cleanup(func() { cleanup(t) })
+
+		getoptlong.OptBuiltins = getoptlong.BuiltinHelp
+
+		getoptlong.Parse(len(args), args, "a", nil, nil)
+
+		if opt := getoptlong.Parse(len(args), args, "a", nil, nil); opt != getoptlong.HelpRequested {
+			t.Errorf("opt is '%d'. Expected '%d'.\n", opt, getoptlong.HelpRequested)
+		}
+
+		if opt := getoptlong.Parse(len(args), args, "a", nil, nil); opt != -1 {
+			t.Errorf("opt is '%d'. Expected '-1'.\n", opt)
+		}
+
+		if getoptlong.OptInd != 2 {
+			t.Errorf("OptInd is '%d'. Expected '2'.\n", getoptlong.OptInd)
+		}
+	})
+
+	t.Run("Version", func(t *testing.T) {
+		args := []string{"prog", "--version"}
+		var longindex int
+		longopts := []getoptlong.Option{
+			{Name: "verbose", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'v'},
+		}
+
+		t.Cleanup(func() { cleanup(t) })
+
+		getoptlong.OptBuiltins = getoptlong.BuiltinVersion
+
+		if opt := getoptlong.Parse(len(args), args, "v", longopts, &longindex); opt != getoptlong.VersionRequested {
+			t.Errorf("opt is '%d'. Expected '%d'.\n", opt, getoptlong.VersionRequested)
+		}
+
+		if longindex != len(longopts) {
+			t.Errorf("longindex is '%d'. Expected '%d'.\n", longindex, len(longopts))
+		}
+
+		args = []string{"prog", "-h"}
+		getoptlong.OptInd = 1
+		getoptlong.OptErr = 0
+
+		if opt := getoptlong.Parse(len(args), args, "v", longopts, nil); opt != '?' {
+			t.Errorf("opt is '%d'. Expected '?'.\n", opt)
+		}
+	})
+
+	t.Run("Help lists built-ins", func(t *testing.T) {
+		expected := "" +
+			"  -v             be verbose\n" +
+			"  -h, --help     display this help and exit\n" +
+			"      --version  output version information and exit\n"
+
+		t.Cleanup(func() { cleanup(t) })
+
+		getoptlong.OptBuiltins = getoptlong.BuiltinHelp | getoptlong.BuiltinVersion
+
+		longopts := []getoptlong.Option{
+			{Name: "", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'v', Description: "be verbose"},
+		}
+
+		if help := getoptlong.FormatHelp("v", longopts, 80); help != expected {
+			t.Errorf("help is '%s'. Expected '%s'.\n", help, expected)
+		}
+	})
+
+	t.Run("Conflicts", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			shortopts string
+			longopts  []getoptlong.Option
+		}{
+			{"short option", "h", nil},
+			{"long option", "", []getoptlong.Option{{Name: "version", HasArg: getoptlong.NoArgument, Flag: nil, Val: 0}}},
+			{"linked short option", "", []getoptlong.Option{{Name: "host", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'h'}}},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				t.Cleanup(func() { cleanup(t) })
+
+				getoptlong.OptBuiltins = getoptlong.BuiltinHelp | getoptlong.BuiltinVersion
+
+				defer func() {
+					if recover() == nil {
+						t.Errorf("Parse did not panic. Expected a conflict.\n")
+					}
+				}()
+
+				getoptlong.Parse(2, []string{"prog", "-x"}, test.shortopts, test.longopts, nil)
+			})
+		}
+	})
+}
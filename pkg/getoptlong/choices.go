@@ -0,0 +1,47 @@
+/*
+	@file      pkg/getoptlong/choices.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+import (
+	"fmt"
+	"strings"
+)
+
+func matchChoice(option Option, arg string) (string, bool) {
+	var matches []string
+
+	for _, choice := range option.Choices {
+		if choice == arg {
+			return choice, false
+		}
+
+		if option.ChoicePrefix && arg != "" && strings.HasPrefix(choice, arg) {
+			matches = append(matches, choice)
+		}
+	}
+
+	if len(matches) == 1 {
+		return matches[0], false
+	}
+
+	return "", len(matches) > 1
+}
+
+func errChoice(progname string, spelling string, arg string, choices []string, ambiguous bool) int {
+	var msg strings.Builder
+
+	if ambiguous {
+		fmt.Fprintf(&msg, "%s: ambiguous argument '%s' for '%s'\nValid arguments are:", progname, arg, spelling)
+	} else {
+		fmt.Fprintf(&msg, "%s: invalid argument '%s' for '%s'\nValid arguments are:", progname, arg, spelling)
+	}
+
+	for _, choice := range choices {
+		fmt.Fprintf(&msg, "\n  - '%s'", choice)
+	}
+
+	return errInvalidOpt(msg.String(), 0)
+}
@@ -0,0 +1,162 @@
+/*
+	@file      pkg/getoptlong/choices_test.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong_test
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/BChristieDev/getopt_long.go/pkg/getoptlong"
+)
+
+func TestChoices(t *testing.T) {
+	t.Run("Valid choice", func(t *testing.T) {
+		args := []string{"", "--color=never", "foo"}
+		longopts := []getoptlong.Option{
+			{Name: "color", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'c', Choices: []string{"auto", "always", "never"}},
+		}
+		var opt int
+
+		t.Cleanup(func() { cleanup(t) })
+
+		for {
+			opt = getoptlong.Parse(len(args), args, "", longopts, nil)
+
+			if opt == -1 {
+				break
+			}
+
+			if opt != 'c' {
+				t.Errorf("opt is '%c'. Expected 'c'.\n", opt)
+			}
+
+			if getoptlong.OptArg != "never" {
+				t.Errorf("optarg is '%s'. Expected 'never'.\n", getoptlong.OptArg)
+			}
+		}
+
+		if args[getoptlong.OptInd] != "foo" {
+			t.Errorf("positional argument is '%s'. Expected 'foo'.\n", args[getoptlong.OptInd])
+		}
+	})
+
+	t.Run("Choice prefix", func(t *testing.T) {
+		args := []string{"", "--color", "nev", "foo"}
+		longopts := []getoptlong.Option{
+			{Name: "color", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'c', Choices: []string{"auto", "always", "never"}, ChoicePrefix: true},
+		}
+		var opt int
+
+		t.Cleanup(func() { cleanup(t) })
+
+		for {
+			opt = getoptlong.Parse(len(args), args, "", longopts, nil)
+
+			if opt == -1 {
+				break
+			}
+
+			if opt != 'c' {
+				t.Errorf("opt is '%c'. Expected 'c'.\n", opt)
+			}
+
+			if getoptlong.OptArg != "never" {
+				t.Errorf("optarg is '%s'. Expected 'never'.\n", getoptlong.OptArg)
+			}
+		}
+
+		if args[getoptlong.OptInd] != "foo" {
+			t.Errorf("positional argument is '%s'. Expected 'foo'.\n", args[getoptlong.OptInd])
+		}
+	})
+
+	t.Run("Ambiguous choice prefix", func(t *testing.T) {
+		args := []string{"getoptlong_test.go", "--color=a"}
+		r, w, _ := os.Pipe()
+		oldStderr := os.Stderr
+		longopts := []getoptlong.Option{
+			{Name: "color", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'c', Choices: []string{"auto", "always", "never"}, ChoicePrefix: true},
+		}
+		var opt int
+
+		t.Cleanup(func() { cleanup(t) })
+
+		os.Stderr = w
+		opt = getoptlong.Parse(len(args), args, "", longopts, nil)
+		w.Close()
+		stderr, _ := io.ReadAll(r)
+		os.Stderr = oldStderr
+
+		if opt != '?' {
+			t.Errorf("opt is '%c'. Expected '?'.\n", opt)
+		}
+
+		expected := "getoptlong_test.go: ambiguous argument 'a' for '--color'\nValid arguments are:\n  - 'auto'\n  - 'always'\n  - 'never'\n"
+
+		if string(stderr) != expected {
+			t.Errorf("stderr is '%s'. Expected '%s'.\n", stderr, expected)
+		}
+	})
+
+	t.Run("Invalid choice", func(t *testing.T) {
+		args := []string{"getoptlong_test.go", "-c", "sometimes"}
+		r, w, _ := os.Pipe()
+		oldStderr := os.Stderr
+		longopts := []getoptlong.Option{
+			{Name: "color", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'c', Choices: []string{"auto", "never"}},
+		}
+		var opt int
+
+		t.Cleanup(func() { cleanup(t) })
+
+		os.Stderr = w
+		opt = getoptlong.Parse(len(args), args, "c:", longopts, nil)
+		w.Close()
+		stderr, _ := io.ReadAll(r)
+		os.Stderr = oldStderr
+
+		if opt != '?' {
+			t.Errorf("opt is '%c'. Expected '?'.\n", opt)
+		}
+
+		if getoptlong.OptOpt != 'c' {
+			t.Errorf("optopt is '%c'. Expected 'c'.\n", getoptlong.OptOpt)
+		}
+
+		expected := "getoptlong_test.go: invalid argument 'sometimes' for '-c'\nValid arguments are:\n  - 'auto'\n  - 'never'\n"
+
+		if string(stderr) != expected {
+			t.Errorf("stderr is '%s'. Expected '%s'.\n", stderr, expected)
+		}
+	})
+
+	t.Run("Optional argument omitted", func(t *testing.T) {
+		args := []string{"", "--color", "foo"}
+		longopts := []getoptlong.Option{
+			{Name: "color", HasArg: getoptlong.OptionalArgument, Flag: nil, Val: 'c', Choices: []string{"auto", "never"}},
+		}
+		var opt int
+
+		t.Cleanup(func() { cleanup(t) })
+
+		for {
+			opt = getoptlong.Parse(len(args), args, "", longopts, nil)
+
+			if opt == -1 {
+				break
+			}
+
+			if opt != 'c' {
+				t.Errorf("opt is '%c'. Expected 'c'.\n", opt)
+			}
+
+			if getoptlong.OptArg != "" {
+				t.Errorf("optarg is '%s'. Expected ''.\n", getoptlong.OptArg)
+			}
+		}
+	})
+}
@@ -0,0 +1,51 @@
+/*
+	@file      pkg/getoptlong/command.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+/* A program, or a subcommand of a program, and its options. */
+type Command struct {
+	/* Name of the command as it is typed. */
+	Name string
+	/* One line summary of the command. */
+	Summary string
+	/* Paragraphs describing the command, separated by blank lines. */
+	Description string
+	/* Short options of the command, see getoptlong.Parse. */
+	Shortopts string
+	/* Long options of the command, see getoptlong.Parse. */
+	Longopts []Option
+	/* Groups of options of the command, see OptGroups. */
+	Groups []Group
+	/* Operands shown after the options in the usage of the command, e.g. "file ...". */
+	Operands []string
+	/* Subcommands of the command. */
+	Commands []*Command
+	/* Aliases expanding in place of a subcommand, see getoptlong.LoadAliases and getoptlong.Execute. */
+	Aliases map[string]string
+	/*
+		Run the executable named after the command path and an operand that is not a subcommand found on
+		PATH, e.g. tool-foo for "tool foo", see getoptlong.Execute.
+	*/
+	Plugins bool
+	/* Accept an unambiguous prefix of the name of a subcommand; only the field of the root command is used. */
+	CommandPrefix bool
+	/*
+		Maximum edit distance of subcommands suggested for an unknown command, negative to suppress
+		suggestions; default 2 like OptSuggest when 0. Only the field of the root command is used.
+	*/
+	CommandSuggest int
+	/* Version written by getoptlong.Execute for --version, see BuiltinVersion. */
+	Version string
+	/*
+		Called by getoptlong.Execute with the result of getoptlong.Parse for each option of the command,
+		whether given before or after a subcommand inheriting it. option is the long option given, or
+		linked to the short option given, and nil for a short option without one. OptArg holds the
+		argument of the option.
+	*/
+	OnOption func(opt int, option *Option)
+	/* Called by getoptlong.Execute with the operands of the command, returning the exit status. */
+	Run func(operands []string) int
+}
@@ -0,0 +1,276 @@
+/*
+	@file      pkg/getoptlong/compat.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+/* A difference between two versions of a command found by getoptlong.CheckCompat. */
+type Change struct {
+	/* Whether an invocation accepted by the old version may be rejected or parsed differently. */
+	Breaking bool
+	/* Path of the command the change is in, e.g. "tool remote". */
+	Command string
+	/* Description of the change. */
+	Message string
+}
+
+var argKinds = []string{"no argument", "a required argument", "an optional argument", "a boolean argument"}
+
+/* Describes the argument of an option with hasArg, or its value if it is not one of the kinds. */
+func argKind(hasArg int) string {
+	return schemaName(argKinds, hasArg)
+}
+
+func (c Change) String() string {
+	return c.Command + ": " + c.Message
+}
+
+type compat struct {
+	path    string
+	prefix  [2]bool
+	changes []Change
+}
+
+func (c *compat) report(breaking bool, format string, args ...any) {
+	c.changes = append(c.changes, Change{Breaking: breaking, Command: c.path, Message: fmt.Sprintf(format, args...)})
+}
+
+func compatibleArg(old, new int, long bool) bool {
+	return old == new || (long && old == NoArgument && (new == OptionalArgument || new == BooleanArgument))
+}
+
+func shortestPrefix(choices []string, choice string) string {
+	for n := 1; n < len(choice); n++ {
+		if _, ambiguous := matchChoice(Option{Choices: choices, ChoicePrefix: true}, choice[:n]); !ambiguous {
+			return choice[:n]
+		}
+	}
+
+	return choice
+}
+
+func (c *compat) checkChoices(old, new *Option) {
+	spelling := "--" + new.Name
+
+	if len(old.Choices) == 0 && len(new.Choices) > 0 {
+		c.report(true, "option '%s' now only accepts '%s'", spelling, strings.Join(new.Choices, "', '"))
+	}
+
+	if len(old.Choices) == 0 || len(new.Choices) == 0 {
+		return
+	}
+
+	for _, choice := range old.Choices {
+		if !slices.Contains(new.Choices, choice) {
+			c.report(true, "argument '%s' of option '%s' was removed", choice, spelling)
+		}
+	}
+
+	for _, choice := range new.Choices {
+		if !slices.Contains(old.Choices, choice) {
+			c.report(false, "argument '%s' of option '%s' was added", choice, spelling)
+		}
+	}
+
+	if !old.ChoicePrefix {
+		return
+	}
+
+	if !new.ChoicePrefix {
+		c.report(true, "option '%s' no longer accepts abbreviated arguments", spelling)
+
+		return
+	}
+
+	for _, choice := range old.Choices {
+		prefix := shortestPrefix(old.Choices, choice)
+
+		if match, ambiguous := matchChoice(*new, prefix); ambiguous {
+			c.report(true, "abbreviation '%s' of argument '%s' of option '%s' is now ambiguous", prefix, choice, spelling)
+		} else if match != "" && match != choice && slices.Contains(new.Choices, choice) {
+			c.report(true, "abbreviation '%s' of argument '%s' of option '%s' now means '%s'", prefix, choice, spelling, match)
+		}
+	}
+}
+
+func (c *compat) checkOptions(old, new *Command) {
+	for i := range old.Longopts {
+		oldOption := &old.Longopts[i]
+
+		if oldOption.Name == "" {
+			continue
+		}
+
+		j := slices.IndexFunc(new.Longopts, func(option Option) bool { return option.Name == oldOption.Name })
+
+		if j == -1 {
+			c.report(true, "option '--%s' was removed", oldOption.Name)
+
+			continue
+		}
+
+		newOption := &new.Longopts[j]
+
+		if !compatibleArg(oldOption.HasArg, newOption.HasArg, true) {
+			c.report(true, "option '--%s' now takes %s instead of %s", newOption.Name, argKind(newOption.HasArg), argKind(oldOption.HasArg))
+		} else if oldOption.HasArg != newOption.HasArg {
+			c.report(false, "option '--%s' now takes %s instead of %s", newOption.Name, argKind(newOption.HasArg), argKind(oldOption.HasArg))
+		}
+
+		if newOption.Required && !oldOption.Required {
+			c.report(true, "option '--%s' is now required", newOption.Name)
+		}
+
+		if oldOption.Env != "" && oldOption.Env != newOption.Env {
+			c.report(true, "option '--%s' no longer reads environment variable %s", newOption.Name, oldOption.Env)
+		}
+
+		if newOption.Env != "" && oldOption.Env != newOption.Env {
+			c.report(false, "option '--%s' now reads environment variable %s", newOption.Name, newOption.Env)
+		}
+
+		c.checkChoices(oldOption, newOption)
+	}
+
+	for _, newOption := range new.Longopts {
+		if newOption.Name == "" || slices.ContainsFunc(old.Longopts, func(option Option) bool { return option.Name == newOption.Name }) {
+			continue
+		}
+
+		c.report(false, "option '--%s' was added", newOption.Name)
+
+		for _, oldOption := range old.Longopts {
+			if oldOption.HasArg == BooleanArgument && OptNoPrefix != "" && newOption.Name == OptNoPrefix+oldOption.Name {
+				c.report(true, "option '--%s' shadows the negation of option '--%s'", newOption.Name, oldOption.Name)
+			}
+		}
+	}
+}
+
+func (c *compat) checkShortOptions(old, new *Command) {
+	shorts := func(cmd *Command) map[int]entry {
+		result := map[int]entry{}
+
+		for _, e := range entries(cmd.Shortopts, cmd.Longopts) {
+			if e.short != 0 {
+				result[e.short] = e
+			}
+		}
+
+		return result
+	}
+	oldShorts, newShorts := shorts(old), shorts(new)
+
+	for _, oldEntry := range entries(old.Shortopts, old.Longopts) {
+		if oldEntry.short == 0 {
+			continue
+		}
+
+		newEntry, ok := newShorts[oldEntry.short]
+
+		if !ok {
+			c.report(true, "option '-%c' was removed", oldEntry.short)
+
+			continue
+		}
+
+		if oldEntry.long() != newEntry.long() {
+			c.report(true, "option '-%c' was reassigned from %s to %s", oldEntry.short, linkedName(oldEntry), linkedName(newEntry))
+		}
+
+		if !compatibleArg(oldEntry.shortArg, newEntry.shortArg, false) {
+			c.report(true, "option '-%c' now takes %s instead of %s", newEntry.short, argKind(newEntry.shortArg), argKind(oldEntry.shortArg))
+		}
+	}
+
+	for _, newEntry := range entries(new.Shortopts, new.Longopts) {
+		if _, ok := oldShorts[newEntry.short]; newEntry.short != 0 && !ok {
+			c.report(false, "option '-%c' was added", newEntry.short)
+		}
+	}
+}
+
+func linkedName(e entry) string {
+	if e.long() == "" {
+		return "no long option"
+	}
+
+	return "'--" + e.long() + "'"
+}
+
+func (c *compat) check(old, new *Command) {
+	c.checkShortOptions(old, new)
+	c.checkOptions(old, new)
+
+	for _, oldSub := range old.Commands {
+		i := slices.IndexFunc(new.Commands, func(sub *Command) bool { return sub.Name == oldSub.Name })
+
+		if i == -1 {
+			c.report(true, "command '%s' was removed", oldSub.Name)
+
+			continue
+		}
+
+		sub := compat{path: c.path + " " + oldSub.Name, prefix: c.prefix}
+		sub.check(oldSub, new.Commands[i])
+		c.changes = append(c.changes, sub.changes...)
+	}
+
+	for _, newSub := range new.Commands {
+		if !slices.ContainsFunc(old.Commands, func(sub *Command) bool { return sub.Name == newSub.Name }) {
+			c.report(false, "command '%s' was added", newSub.Name)
+		}
+	}
+
+	c.checkCommandPrefixes(old, new)
+}
+
+func (c *compat) checkCommandPrefixes(old, new *Command) {
+	var names []string
+
+	if !c.prefix[0] || !c.prefix[1] {
+		return
+	}
+
+	for _, sub := range old.Commands {
+		names = append(names, sub.Name)
+	}
+
+	for _, name := range names {
+		prefix := shortestPrefix(names, name)
+		match, ambiguous := findCommand(new.Commands, prefix, true)
+
+		if len(ambiguous) > 0 {
+			c.report(true, "abbreviation '%s' of command '%s' is now ambiguous", prefix, name)
+		} else if match != nil && match.Name != name {
+			c.report(true, "abbreviation '%s' of command '%s' now means '%s'", prefix, name, match.Name)
+		}
+	}
+}
+
+/*
+Compares two versions of a command and its subcommands, reporting removed and reassigned options,
+options and arguments that became stricter, and abbreviations of choices, and of subcommands when
+CommandPrefix of both roots is set, that became ambiguous as breaking changes, and added options,
+arguments and commands as additive changes. Long options are matched exactly by getoptlong.Parse,
+so adding an option can only break an invocation by shadowing the negation of a BooleanArgument
+option.
+*/
+func CheckCompat(old, new *Command) []Change {
+	c := compat{path: new.Name, prefix: [2]bool{old.CommandPrefix, new.CommandPrefix}}
+
+	if old.CommandPrefix && !new.CommandPrefix {
+		c.report(true, "commands no longer accept abbreviated names")
+	}
+
+	c.check(old, new)
+
+	return c.changes
+}
@@ -0,0 +1,107 @@
+/*
+	@file      pkg/getoptlong/compat_test.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/BChristieDev/getopt_long.go/pkg/getoptlong"
+)
+
+func TestCheckCompat(t *testing.T) {
+	old := &getoptlong.Command{
+		Name:      "tool",
+		Shortopts: "vo:x",
+		Longopts: []getoptlong.Option{
+			{Name: "verbose", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'v'},
+			{Name: "output", HasArg: getoptlong.OptionalArgument, Flag: nil, Val: 'o'},
+			{Name: "color", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 0, Choices: []string{"auto", "never"}, ChoicePrefix: true},
+			{Name: "frob", HasArg: getoptlong.BooleanArgument, Flag: nil, Val: 0},
+			{Name: "quiet", HasArg: getoptlong.NoArgument, Flag: nil, Val: 0},
+			{Name: "dry-run", HasArg: getoptlong.NoArgument, Flag: nil, Val: 0},
+		},
+		Commands: []*getoptlong.Command{{Name: "push"}, {Name: "pull"}},
+	}
+	new := &getoptlong.Command{
+		Name:      "tool",
+		Shortopts: "vo:xn",
+		Longopts: []getoptlong.Option{
+			{Name: "verbose", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'v'},
+			{Name: "output", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'o'},
+			{Name: "exclude", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'x'},
+			{Name: "color", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 0, Choices: []string{"auto", "always", "never"}, ChoicePrefix: true},
+			{Name: "frob", HasArg: getoptlong.BooleanArgument, Flag: nil, Val: 0},
+			{Name: "no-frob", HasArg: getoptlong.NoArgument, Flag: nil, Val: 0},
+			{Name: "dry-run", HasArg: getoptlong.BooleanArgument, Flag: nil, Val: 'n'},
+		},
+		Commands: []*getoptlong.Command{{Name: "push", Shortopts: "f"}, {Name: "fetch"}},
+	}
+	expected := []getoptlong.Change{
+		{Breaking: true, Command: "tool", Message: "option '-x' was reassigned from no long option to '--exclude'"},
+		{Breaking: false, Command: "tool", Message: "option '-n' was added"},
+		{Breaking: true, Command: "tool", Message: "option '--output' now takes a required argument instead of an optional argument"},
+		{Breaking: false, Command: "tool", Message: "argument 'always' of option '--color' was added"},
+		{Breaking: true, Command: "tool", Message: "abbreviation 'a' of argument 'auto' of option '--color' is now ambiguous"},
+		{Breaking: true, Command: "tool", Message: "option '--quiet' was removed"},
+		{Breaking: false, Command: "tool", Message: "option '--dry-run' now takes a boolean argument instead of no argument"},
+		{Breaking: false, Command: "tool", Message: "option '--exclude' was added"},
+		{Breaking: false, Command: "tool", Message: "option '--no-frob' was added"},
+		{Breaking: true, Command: "tool", Message: "option '--no-frob' shadows the negation of option '--frob'"},
+		{Breaking: false, Command: "tool push", Message: "option '-f' was added"},
+		{Breaking: true, Command: "tool", Message: "command 'pull' was removed"},
+		{Breaking: false, Command: "tool", Message: "command 'fetch' was added"},
+	}
+	actual := getoptlong.CheckCompat(old, new)
+
+	if len(actual) != len(expected) {
+		t.Fatalf("changes are '%v'. Expected '%v'.\n", actual, expected)
+	}
+
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("change %d is '%+v'. Expected '%+v'.\n", i, actual[i], expected[i])
+		}
+	}
+
+	t.Run("Same spec", func(t *testing.T) {
+		if changes := getoptlong.CheckCompat(docsCommand, docsCommand); len(changes) != 0 {
+			t.Errorf("changes are '%v'. Expected none.\n", changes)
+		}
+	})
+
+	t.Run("Command abbreviation", func(t *testing.T) {
+		old := &getoptlong.Command{Name: "tool", CommandPrefix: true, Commands: []*getoptlong.Command{{Name: "status"}, {Name: "show"}}}
+		new := &getoptlong.Command{Name: "tool", CommandPrefix: true, Commands: []*getoptlong.Command{{Name: "status"}, {Name: "show"}, {Name: "stash"}}}
+		expected := []getoptlong.Change{
+			{Breaking: false, Command: "tool", Message: "command 'stash' was added"},
+			{Breaking: true, Command: "tool", Message: "abbreviation 'st' of command 'status' is now ambiguous"},
+		}
+		actual := getoptlong.CheckCompat(old, new)
+
+		if !slices.Equal(actual, expected) {
+			t.Errorf("changes are '%v'. Expected '%v'.\n", actual, expected)
+		}
+	})
+
+	t.Run("Short option argument", func(t *testing.T) {
+		changes := getoptlong.CheckCompat(&getoptlong.Command{Name: "prog", Shortopts: "a"}, &getoptlong.Command{Name: "prog", Shortopts: "a::"})
+
+		if len(changes) != 1 || !changes[0].Breaking || changes[0].String() != "prog: option '-a' now takes an optional argument instead of no argument" {
+			t.Errorf("changes are '%v'. Expected a breaking change to '-a'.\n", changes)
+		}
+	})
+
+	t.Run("Unknown argument kind", func(t *testing.T) {
+		old := &getoptlong.Command{Name: "prog", Longopts: []getoptlong.Option{{Name: "a", HasArg: getoptlong.NoArgument, Flag: nil, Val: 0}}}
+		new := &getoptlong.Command{Name: "prog", Longopts: []getoptlong.Option{{Name: "a", HasArg: 7, Flag: nil, Val: 0}}}
+		expected := []getoptlong.Change{{Breaking: true, Command: "prog", Message: "option '--a' now takes 7 instead of no argument"}}
+
+		if actual := getoptlong.CheckCompat(old, new); !slices.Equal(actual, expected) {
+			t.Errorf("changes are '%v'. Expected '%v'.\n", actual, expected)
+		}
+	})
+}
@@ -0,0 +1,183 @@
+/*
+	@file      pkg/getoptlong/complete.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/BChristieDev/getopt_long.go/internal/common"
+)
+
+/* Hidden first argument asking the program to complete its last argument, see getoptlong.Complete. */
+const CompleteCommand = "__complete"
+
+func completeArg(option *Option, arg string) []string {
+	var candidates, values []string
+
+	switch {
+	case option == nil:
+	case option.Complete != nil:
+		values = option.Complete(arg)
+	case option.HasArg == BooleanArgument:
+		values = boolChoices
+	default:
+		values = option.Choices
+	}
+
+	for _, value := range values {
+		if strings.HasPrefix(value, arg) {
+			candidates = append(candidates, value)
+		}
+	}
+
+	return candidates
+}
+
+func completeLongOpt(cur string, longopts []Option) []string {
+	var candidates []string
+
+	if eq := strings.Index(cur, "="); eq != -1 {
+		optarrind := common.FindIndex(longopts, func(longopt Option) bool { return longopt.Name == cur[2:eq] })
+
+		if optarrind == -1 || longopts[optarrind].HasArg == NoArgument {
+			return nil
+		}
+
+		return completeArg(&longopts[optarrind], cur[eq+1:])
+	}
+
+	for _, longopt := range longopts {
+		var names []string
+
+		if longopt.Name == "" || longopt.Hidden {
+			continue
+		}
+
+		switch longopt.HasArg {
+		case OptionalArgument:
+			names = []string{"--" + longopt.Name + "="}
+		case BooleanArgument:
+			names = []string{"--" + longopt.Name, "--" + OptNoPrefix + longopt.Name}
+		default:
+			names = []string{"--" + longopt.Name}
+		}
+
+		for _, name := range names {
+			if strings.HasPrefix(name, cur) {
+				candidates = append(candidates, name)
+			}
+		}
+	}
+
+	return candidates
+}
+
+func completeShortOpt(cur string, shortopts string, longopts []Option) []string {
+	var candidates []string
+
+	if cur == "-" {
+		for _, e := range entries(shortopts, longopts) {
+			if e.short != 0 && !e.hidden() {
+				candidates = append(candidates, fmt.Sprintf("-%c", e.short))
+			}
+		}
+
+		return append(candidates, completeLongOpt("--", longopts)...)
+	}
+
+	for i := 1; i < len(cur); i++ {
+		optstrind := strings.Index(shortopts, cur[i:i+1])
+
+		if cur[i] == ':' || optstrind == -1 {
+			return nil
+		}
+
+		if common.CharAt(shortopts, optstrind+1) != ":" {
+			continue
+		}
+
+		var option *Option
+
+		if optarrind := findShortOpt(longopts, int(cur[i])); optarrind != -1 {
+			option = &longopts[optarrind]
+		}
+
+		for _, value := range completeArg(option, cur[i+1:]) {
+			candidates = append(candidates, cur[:i+1]+value)
+		}
+
+		return candidates
+	}
+
+	return []string{cur}
+}
+
+/*
+If argv[1] is CompleteCommand, writes the candidates completing the last element of argv to w, one
+per line, and returns true, otherwise returns false. The elements in between are parsed like
+getoptlong.Parse parses them, to tell whether the last element is an option, the argument of an
+option, or an operand. Arguments are completed by the Complete function of the option, or from its
+Choices. A candidate replaces the text following "=" when completing --name=ARG, and the whole
+element otherwise; operands are not completed.
+*/
+func Complete(w io.Writer, argv []string, shortopts string, longopts []Option) bool {
+	if len(argv) < 3 || argv[1] != CompleteCommand {
+		return false
+	}
+
+	shortopts, longopts = withBuiltins(shortopts, longopts)
+
+	var candidates []string
+	var pending *Option
+	var longindex, opt int
+	waiting := false
+	args := append([]string{argv[0]}, argv[2:len(argv)-1]...)
+	cur := argv[len(argv)-1]
+	optind, opterr, builtins := OptInd, OptErr, OptBuiltins
+
+	OptInd = 0
+	OptBuiltins = 0
+
+	for {
+		opt = Parse(len(args), args, ":"+strings.TrimPrefix(shortopts, ":"), longopts, &longindex)
+
+		if opt == -1 {
+			break
+		}
+
+		if opt != ':' || OptInd < len(args) {
+			continue
+		}
+
+		waiting = true
+
+		if OptOpt == 0 {
+			pending = &longopts[longindex]
+		} else if optarrind := findShortOpt(longopts, OptOpt); optarrind != -1 {
+			pending = &longopts[optarrind]
+		}
+	}
+
+	switch {
+	case waiting:
+		candidates = completeArg(pending, cur)
+	case OptInd < len(args) || dashdash:
+	case strings.HasPrefix(cur, "--"):
+		candidates = completeLongOpt(cur, longopts)
+	case strings.HasPrefix(cur, "-"):
+		candidates = completeShortOpt(cur, shortopts, longopts)
+	}
+
+	for _, candidate := range candidates {
+		fmt.Fprintln(w, candidate)
+	}
+
+	OptInd, OptErr, OptBuiltins = optind, opterr, builtins
+
+	return true
+}
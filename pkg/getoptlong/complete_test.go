@@ -0,0 +1,65 @@
+/*
+	@file      pkg/getoptlong/complete_test.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/BChristieDev/getopt_long.go/pkg/getoptlong"
+)
+
+func TestComplete(t *testing.T) {
+	longopts := []getoptlong.Option{
+		{Name: "cluster", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'c', Complete: func(arg string) []string {
+			return []string{"prod-eu", "prod-us", "staging"}
+		}},
+		{Name: "color", HasArg: getoptlong.OptionalArgument, Flag: nil, Val: 0, Choices: []string{"auto", "never"}},
+		{Name: "verbose", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'v'},
+		{Name: "debug", HasArg: getoptlong.NoArgument, Flag: nil, Val: 0, Hidden: true},
+	}
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"Pending required argument", []string{"-v", "--cluster", "prod"}, "prod-eu\nprod-us\n"},
+		{"Pending short option argument", []string{"-vc", ""}, "prod-eu\nprod-us\nstaging\n"},
+		{"Argument after equals sign", []string{"--color=a"}, "auto\n"},
+		{"Argument in cluster", []string{"-vcst"}, "-vcstaging\n"},
+		{"Long option names", []string{"--c"}, "--cluster\n--color=\n"},
+		{"Short option names", []string{"-"}, "-c\n-v\n--cluster\n--color=\n--verbose\n"},
+		{"Operand", []string{"foo", "-"}, ""},
+		{"End of options delimiter", []string{"--", "-"}, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var stdout strings.Builder
+			args := append([]string{"prog", getoptlong.CompleteCommand}, test.args...)
+
+			t.Cleanup(func() { cleanup(t) })
+
+			if !getoptlong.Complete(&stdout, args, "c:v", longopts) {
+				t.Fatalf("complete is 'false'. Expected 'true'.\n")
+			}
+
+			if stdout.String() != test.expected {
+				t.Errorf("stdout is '%s'. Expected '%s'.\n", stdout.String(), test.expected)
+			}
+		})
+	}
+
+	t.Run("Not completing", func(t *testing.T) {
+		var stdout strings.Builder
+
+		t.Cleanup(func() { cleanup(t) })
+
+		if getoptlong.Complete(&stdout, []string{"prog", "--cluster", "foo"}, "c:v", longopts) {
+			t.Errorf("complete is 'true'. Expected 'false'.\n")
+		}
+	})
+}
@@ -0,0 +1,312 @@
+/*
+	@file      pkg/getoptlong/completion.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/BChristieDev/getopt_long.go/internal/common"
+)
+
+func shellName(progname string) string {
+	return regexp.MustCompile("[^A-Za-z0-9_]").ReplaceAllString(progname, "_")
+}
+
+func shellQuote(text string) string {
+	return "'" + strings.ReplaceAll(text, "'", `'\''`) + "'"
+}
+
+func (e entry) spellings() []string {
+	var spellings []string
+
+	if e.short != 0 {
+		spellings = append(spellings, fmt.Sprintf("-%c", e.short))
+	}
+
+	if e.long() != "" {
+		spellings = append(spellings, "--"+e.long())
+	}
+
+	return spellings
+}
+
+func (e entry) dynamic() bool {
+	return e.option != nil && e.option.Complete != nil
+}
+
+func (e entry) choices() []string {
+	if e.option == nil || e.option.HasArg == BooleanArgument {
+		return nil
+	}
+
+	return e.option.Choices
+}
+
+/*
+Formats a bash completion script for progname. Arguments of required argument options are completed
+by running progname with CompleteCommand if they have a Complete function, from their Choices, or as
+file names; optional argument options are only completed in the --name=ARG form. Nothing after "--"
+is completed as an option.
+*/
+func FormatBashCompletion(progname string, shortopts string, longopts []Option) string {
+	shortopts, longopts = withBuiltins(shortopts, longopts)
+
+	var words, required, optional []string
+	var bash strings.Builder
+	name := shellName(progname)
+
+	for _, e := range entries(shortopts, longopts) {
+		if e.hidden() {
+			continue
+		}
+
+		values := "return"
+
+		if e.dynamic() {
+			values = "mapfile -t COMPREPLY < <(\"${COMP_WORDS[0]}\" " + CompleteCommand + " \"${words[@]}\" 2>/dev/null); return"
+		} else if len(e.choices()) > 0 {
+			values = fmt.Sprintf("COMPREPLY=($(compgen -W %s -- \"$value\")); return", shellQuote(strings.Join(e.choices(), " ")))
+		}
+
+		var separate []string
+
+		if e.short != 0 {
+			words = append(words, fmt.Sprintf("-%c", e.short))
+
+			if e.shortArg == RequiredArgument {
+				separate = append(separate, fmt.Sprintf("-%c", e.short))
+			}
+		}
+
+		if e.long() != "" && e.option.HasArg == RequiredArgument {
+			separate = append(separate, "--"+e.long())
+		}
+
+		if len(separate) > 0 {
+			required = append(required, fmt.Sprintf("        %s) %s ;;\n", strings.Join(separate, "|"), strings.Replace(values, "$value", "$cur", 1)))
+		}
+
+		if e.long() == "" {
+			continue
+		}
+
+		switch e.option.HasArg {
+		case RequiredArgument:
+			words = append(words, "--"+e.long())
+			optional = append(optional, fmt.Sprintf("        --%s=*) %s ;;\n", e.long(), values))
+		case OptionalArgument:
+			words = append(words, "--"+e.long()+"=")
+			optional = append(optional, fmt.Sprintf("        --%s=*) %s ;;\n", e.long(), values))
+		case BooleanArgument:
+			words = append(words, "--"+e.long(), "--"+OptNoPrefix+e.long())
+		default:
+			words = append(words, "--"+e.long())
+		}
+	}
+
+	fmt.Fprintf(&bash, "# bash completion for %s\n\n", progname)
+	fmt.Fprintf(&bash, "_%s()\n{\n", name)
+	bash.WriteString("    local cur prev value i\n")
+	bash.WriteString("    local -a words=()\n\n")
+	bash.WriteString("    for ((i = 1; i <= COMP_CWORD; i++)); do\n")
+	bash.WriteString("        if ((${#words[@]} > 0)) && [[ ${COMP_WORDS[i]} == = || ${COMP_WORDS[i-1]} == = ]]; then\n")
+	bash.WriteString("            words[${#words[@]}-1]+=${COMP_WORDS[i]}\n")
+	bash.WriteString("        else\n")
+	bash.WriteString("            words+=(\"${COMP_WORDS[i]}\")\n")
+	bash.WriteString("        fi\n")
+	bash.WriteString("    done\n\n")
+	bash.WriteString("    cur=${words[${#words[@]}-1]}\n")
+	bash.WriteString("    prev=\n\n")
+	bash.WriteString("    for ((i = 0; i < ${#words[@]} - 1; i++)); do\n")
+	bash.WriteString("        [[ ${words[i]} == -- ]] && return\n")
+	bash.WriteString("        prev=${words[i]}\n")
+	bash.WriteString("    done\n\n")
+
+	if len(required) > 0 {
+		bash.WriteString("    case $prev in\n")
+		bash.WriteString(strings.Join(required, ""))
+		bash.WriteString("    esac\n\n")
+	}
+
+	bash.WriteString("    value=${cur#*=}\n\n")
+	bash.WriteString("    case $cur in\n")
+	bash.WriteString(strings.Join(optional, ""))
+	fmt.Fprintf(&bash, "        -*)\n            COMPREPLY=($(compgen -W %s -- \"$cur\"))\n", shellQuote(strings.Join(words, " ")))
+	bash.WriteString("            [[ ${COMPREPLY[*]} == *= ]] && compopt -o nospace\n")
+	bash.WriteString("            ;;\n")
+	bash.WriteString("    esac\n")
+	bash.WriteString("}\n\n")
+	fmt.Fprintf(&bash, "complete -o default -F _%s %s\n", name, progname)
+
+	return bash.String()
+}
+
+func zshEscape(text string) string {
+	return strings.NewReplacer("'", `'\''`, `\`, `\\`, "[", `\[`, "]", `\]`, ":", `\:`).Replace(text)
+}
+
+/*
+Formats a zsh completion script for progname, see getoptlong.FormatBashCompletion.
+*/
+func FormatZshCompletion(progname string, shortopts string, longopts []Option) string {
+	shortopts, longopts = withBuiltins(shortopts, longopts)
+
+	var zsh strings.Builder
+
+	fmt.Fprintf(&zsh, "#compdef %s\n\n", progname)
+
+	if common.FindIndex(entries(shortopts, longopts), entry.dynamic) != -1 {
+		fmt.Fprintf(&zsh, "_%s_complete()\n{\n", shellName(progname))
+		zsh.WriteString("    local -a candidates\n\n")
+		fmt.Fprintf(&zsh, "    candidates=(\"${(@f)$(${words[1]} %s \"${(@)words[2,CURRENT]}\" 2>/dev/null)}\")\n", CompleteCommand)
+		zsh.WriteString("    compadd -- \"${(@)candidates#$IPREFIX}\"\n")
+		zsh.WriteString("}\n\n")
+	}
+
+	zsh.WriteString("_arguments -s -S \\\n")
+
+	for _, e := range entries(shortopts, longopts) {
+		var spellings []string
+		var action string
+		names := e.spellings()
+
+		if e.hidden() {
+			continue
+		}
+
+		switch {
+		case e.dynamic():
+			action = fmt.Sprintf(":%s:_%s_complete", zshEscape(e.argName()), shellName(progname))
+		case len(e.choices()) > 0:
+			action = fmt.Sprintf(":%s:(%s)", zshEscape(e.argName()), zshEscape(strings.Join(e.choices(), " ")))
+		default:
+			action = fmt.Sprintf(":%s:_files", zshEscape(e.argName()))
+		}
+
+		if e.short != 0 {
+			switch e.shortArg {
+			case RequiredArgument:
+				spellings = append(spellings, fmt.Sprintf("-%c+", e.short))
+			case OptionalArgument:
+				spellings = append(spellings, fmt.Sprintf("-%c-", e.short))
+			default:
+				spellings = append(spellings, fmt.Sprintf("-%c", e.short))
+			}
+		}
+
+		if e.long() != "" {
+			switch e.option.HasArg {
+			case RequiredArgument:
+				spellings = append(spellings, "--"+e.long()+"=")
+			case OptionalArgument:
+				spellings = append(spellings, "--"+e.long()+"=-")
+			case BooleanArgument:
+				spellings = append(spellings, "--"+e.long(), "--"+OptNoPrefix+e.long())
+				names = append(names, "--"+OptNoPrefix+e.long())
+			default:
+				spellings = append(spellings, "--"+e.long())
+			}
+		}
+
+		switch e.hasArg() {
+		case RequiredArgument:
+		case OptionalArgument:
+			action = ":" + action
+		default:
+			action = ""
+		}
+
+		description := action + "'"
+
+		if e.description() != "" {
+			description = fmt.Sprintf("[%s]%s'", zshEscape(e.description()), action)
+		}
+
+		if len(spellings) == 1 {
+			fmt.Fprintf(&zsh, "    '%s%s \\\n", spellings[0], description)
+			continue
+		}
+
+		fmt.Fprintf(&zsh, "    '(%s)'{%s}'%s \\\n", strings.Join(names, " "), strings.Join(spellings, ","), description)
+	}
+
+	zsh.WriteString("    '*:file:_files'\n")
+
+	return zsh.String()
+}
+
+func fishQuote(text string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(text) + "'"
+}
+
+/*
+Formats a fish completion script for progname, see getoptlong.FormatBashCompletion.
+*/
+func FormatFishCompletion(progname string, shortopts string, longopts []Option) string {
+	shortopts, longopts = withBuiltins(shortopts, longopts)
+
+	var fish strings.Builder
+	condition := fmt.Sprintf("__%s_options", shellName(progname))
+
+	fmt.Fprintf(&fish, "# fish completion for %s\n\n", progname)
+	fmt.Fprintf(&fish, "function %s\n    not contains -- -- (commandline -opc)\nend\n\n", condition)
+
+	if common.FindIndex(entries(shortopts, longopts), entry.dynamic) != -1 {
+		fmt.Fprintf(&fish, "function __%s_complete\n", shellName(progname))
+		fish.WriteString("    set -l words (commandline -opc) (commandline -ct)\n")
+		fmt.Fprintf(&fish, "    $words[1] %s $words[2..-1] 2>/dev/null\nend\n\n", CompleteCommand)
+	}
+
+	for _, e := range entries(shortopts, longopts) {
+		var names []string
+
+		if e.hidden() {
+			continue
+		}
+
+		if e.short != 0 {
+			names = append(names, fmt.Sprintf("-s %c", e.short))
+		}
+
+		if e.long() != "" {
+			names = append(names, "-l "+fishQuote(e.long()))
+		}
+
+		line := fmt.Sprintf("complete -c %s -n %s %s", progname, condition, strings.Join(names, " "))
+
+		if e.hasArg() == RequiredArgument && (e.dynamic() || len(e.choices()) > 0) {
+			line += " -x"
+		} else if e.hasArg() == RequiredArgument {
+			line += " -r"
+		}
+
+		if (e.hasArg() == RequiredArgument || e.hasArg() == OptionalArgument) && e.dynamic() {
+			line += fmt.Sprintf(" -a '(__%s_complete)'", shellName(progname))
+		} else if (e.hasArg() == RequiredArgument || e.hasArg() == OptionalArgument) && len(e.choices()) > 0 {
+			line += " -a " + fishQuote(strings.Join(e.choices(), " "))
+		}
+
+		if e.description() != "" {
+			line += " -d " + fishQuote(e.description())
+		}
+
+		fish.WriteString(line + "\n")
+
+		if e.long() != "" && e.option.HasArg == BooleanArgument {
+			line = fmt.Sprintf("complete -c %s -n %s -l %s", progname, condition, fishQuote(OptNoPrefix+e.long()))
+
+			if e.description() != "" {
+				line += " -d " + fishQuote(e.description())
+			}
+
+			fish.WriteString(line + "\n")
+		}
+	}
+
+	return fish.String()
+}
@@ -0,0 +1,36 @@
+/*
+	@file      pkg/getoptlong/completion_test.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong_test
+
+import (
+	"testing"
+
+	"github.com/BChristieDev/getopt_long.go/pkg/getoptlong"
+)
+
+var completionLongopts = []getoptlong.Option{
+	{Name: "add", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 0, Description: "add an entry"},
+	{Name: "bar", HasArg: getoptlong.OptionalArgument, Flag: nil, Val: 0, Description: "maybe bar"},
+	{Name: "color", HasArg: getoptlong.OptionalArgument, Flag: nil, Val: 0, Description: "colorize", Choices: []string{"auto", "never"}},
+	{Name: "create", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'c', ArgName: "NAME", Description: "create [NAME]", Choices: []string{"a", "b"}},
+	{Name: "verbose", HasArg: getoptlong.BooleanArgument, Flag: nil, Val: 'v', Description: "don't be quiet"},
+	{Name: "debug", HasArg: getoptlong.NoArgument, Flag: nil, Val: 0, Hidden: true},
+	{Name: "cluster", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 0, Complete: func(arg string) []string { return nil }},
+}
+
+func TestCompletion(t *testing.T) {
+	t.Run("Bash", func(t *testing.T) {
+		golden(t, "completion.bash.golden", getoptlong.FormatBashCompletion("prog", "ac:d::v", completionLongopts))
+	})
+
+	t.Run("Zsh", func(t *testing.T) {
+		golden(t, "completion.zsh.golden", getoptlong.FormatZshCompletion("prog", "ac:d::v", completionLongopts))
+	})
+
+	t.Run("Fish", func(t *testing.T) {
+		golden(t, "completion.fish.golden", getoptlong.FormatFishCompletion("prog", "ac:d::v", completionLongopts))
+	})
+}
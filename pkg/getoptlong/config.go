@@ -0,0 +1,170 @@
+/*
+	@file      pkg/getoptlong/config.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+/* A value of a long option read from a config file, see getoptlong.LoadConfig. */
+type ConfigValue struct {
+	/* Argument of the option. */
+	Value string
+	/* File and line the value was read from, e.g. "app.ini:3". */
+	Origin string
+}
+
+/* Values of long options by name, used for the options not given in argv or their Env. */
+var OptConfig = map[string]ConfigValue{}
+
+func checkConfigValue(option *Option, value string) error {
+	switch option.HasArg {
+	case NoArgument, BooleanArgument:
+		if _, ok := parseBool(value, true); !ok {
+			return fmt.Errorf("invalid argument '%s' for '%s', expected one of '%s'", value, option.Name, strings.Join(boolChoices, "', '"))
+		}
+	case RequiredArgument:
+		if value == "" {
+			return fmt.Errorf("option '%s' requires an argument", option.Name)
+		}
+	}
+
+	if len(option.Choices) > 0 && option.HasArg != NoArgument && option.HasArg != BooleanArgument && value != "" {
+		if choice, ambiguous := matchChoice(*option, value); choice == "" && ambiguous {
+			return fmt.Errorf("ambiguous argument '%s' for '%s'", value, option.Name)
+		} else if choice == "" {
+			return fmt.Errorf("invalid argument '%s' for '%s', expected one of '%s'", value, option.Name, strings.Join(option.Choices, "', '"))
+		}
+	}
+
+	return nil
+}
+
+/*
+Reads the lines "name = value" of the file at path into OptConfig, where name is the name of one of
+longopts, ignoring blank lines and lines starting with '#' or ';'. The value of an option without an
+argument is a boolean like the value of its Env. Values are validated like arguments, and an error
+naming the file and line is returned, leaving OptConfig unchanged, if one is invalid or its option
+is unknown. Values of a file read later replace the ones read before.
+*/
+func LoadConfig(path string, longopts []Option) error {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	values := map[string]ConfigValue{}
+	scanner := bufio.NewScanner(file)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+
+		if text == "" || text[0] == '#' || text[0] == ';' {
+			continue
+		}
+
+		name, value, ok := strings.Cut(text, "=")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+
+		if !ok {
+			return fmt.Errorf("%s:%d: expected 'name = value'", path, line)
+		}
+
+		optarrind := slices.IndexFunc(longopts, func(longopt Option) bool { return longopt.Name == name })
+
+		if name == "" || optarrind == -1 {
+			return fmt.Errorf("%s:%d: unrecognized option '%s'", path, line, name)
+		}
+
+		if err := checkConfigValue(&longopts[optarrind], value); err != nil {
+			return fmt.Errorf("%s:%d: %v", path, line, err)
+		}
+
+		values[name] = ConfigValue{Value: value, Origin: fmt.Sprintf("%s:%d", path, line)}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	for name, value := range values {
+		OptConfig[name] = value
+	}
+
+	return nil
+}
+
+/*
+Returns where the value of the option name reported by getoptlong.Value comes from: "argv[N]", along
+with the origin of the element if it has one, e.g. "argv[2] from args.txt:1", "environment variable
+NAME", "file:line" for a config file, "default", or "" if it has no value.
+*/
+func Source(name string) string {
+	key := lookupKey(specLongopts, name)
+
+	if occurrence := occurrences[key]; len(occurrence) > 0 {
+		last := occurrence[len(occurrence)-1]
+
+		if last.Index < 0 {
+			return last.Origin
+		}
+
+		if last.Origin != "" {
+			return fmt.Sprintf("argv[%d] from %s", last.Index, last.Origin)
+		}
+
+		return fmt.Sprintf("argv[%d]", last.Index)
+	}
+
+	for optarrind := range specLongopts {
+		if optionKey(specLongopts, optarrind) == key && specLongopts[optarrind].Default != "" {
+			return "default"
+		}
+	}
+
+	return ""
+}
+
+/*
+Formats the effective value of each long option with a value once all options are parsed as a
+config file getoptlong.LoadConfig can read, each preceded by a comment naming its getoptlong.Source.
+The value of an option without an argument is true.
+*/
+func FormatConfig(longopts []Option) string {
+	var config strings.Builder
+
+	for _, longopt := range longopts {
+		key := "--" + longopt.Name
+		source := Source(key)
+
+		if longopt.Name == "" || source == "" {
+			continue
+		}
+
+		value := Value(key)
+
+		if longopt.HasArg == NoArgument {
+			value = formatBool(true)
+		}
+
+		fmt.Fprintf(&config, "# %s\n%s = %s\n", source, longopt.Name, value)
+	}
+
+	return config.String()
+}
+
+/* Writes getoptlong.FormatConfig to w. */
+func PrintConfig(w io.Writer, longopts []Option) {
+	io.WriteString(w, FormatConfig(longopts))
+}
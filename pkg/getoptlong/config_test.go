@@ -0,0 +1,124 @@
+/*
+	@file      pkg/getoptlong/config_test.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BChristieDev/getopt_long.go/pkg/getoptlong"
+)
+
+var configLongopts = []getoptlong.Option{
+	{Name: "listen", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'l', Env: "APP_LISTEN", Default: ":80"},
+	{Name: "log-level", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 0, Choices: []string{"debug", "info"}, Default: "info"},
+	{Name: "workers", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'w', Env: "APP_WORKERS"},
+	{Name: "verbose", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'v'},
+	{Name: "color", HasArg: getoptlong.OptionalArgument, Flag: nil, Val: 0},
+}
+
+func writeConfig(t *testing.T, config string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "app.ini")
+
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Run("Values", func(t *testing.T) {
+		path := writeConfig(t, "# app\nlisten = :8080\n\nlog-level=debug\nverbose = yes\n")
+
+		t.Cleanup(func() { cleanup(t) })
+
+		if err := getoptlong.LoadConfig(path, configLongopts); err != nil {
+			t.Fatal(err)
+		}
+
+		if value := getoptlong.OptConfig["log-level"]; value.Value != "debug" || value.Origin != path+":4" {
+			t.Errorf("log-level is '%+v'. Expected 'debug' from '%s:4'.\n", value, path)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			config   string
+			expected string
+		}{
+			{"Unknown option", "listen = :80\nport = 80\n", ":2: unrecognized option 'port'"},
+			{"Missing argument", "listen =\n", ":1: option 'listen' requires an argument"},
+			{"Boolean", "verbose = maybe\n", ":1: invalid argument 'maybe' for 'verbose', expected one of 'yes', 'no', 'true', 'false', '1', '0'"},
+			{"Choices", "log-level = trace\n", ":1: invalid argument 'trace' for 'log-level', expected one of 'debug', 'info'"},
+			{"Malformed line", "[server]\n", ":1: expected 'name = value'"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				path := writeConfig(t, test.config)
+
+				t.Cleanup(func() { cleanup(t) })
+
+				if err := getoptlong.LoadConfig(path, configLongopts); err == nil || err.Error() != path+test.expected {
+					t.Errorf("error is '%v'. Expected '%s'.\n", err, path+test.expected)
+				}
+
+				if len(getoptlong.OptConfig) != 0 {
+					t.Errorf("OptConfig is '%v'. Expected it unchanged.\n", getoptlong.OptConfig)
+				}
+			})
+		}
+	})
+}
+
+func TestLayers(t *testing.T) {
+	path := writeConfig(t, "listen = :8080\nworkers = 2\nverbose = true\ncolor =\n")
+	args := []string{"prog", "-w", "4"}
+	expected := "" +
+		"# environment variable APP_LISTEN\n" +
+		"listen = :9090\n" +
+		"# default\n" +
+		"log-level = info\n" +
+		"# argv[1]\n" +
+		"workers = 4\n" +
+		"# " + path + ":3\n" +
+		"verbose = true\n" +
+		"# " + path + ":4\n" +
+		"color = \n"
+
+	t.Setenv("APP_LISTEN", ":9090")
+	t.Setenv("APP_WORKERS", "8")
+	t.Cleanup(func() { cleanup(t) })
+
+	if err := getoptlong.LoadConfig(path, configLongopts); err != nil {
+		t.Fatal(err)
+	}
+
+	for getoptlong.Parse(len(args), args, "l:w:v", configLongopts, nil) != -1 {
+	}
+
+	sources := map[string]string{
+		"--listen":    "environment variable APP_LISTEN",
+		"--log-level": "default",
+		"-w":          "argv[1]",
+		"-v":          path + ":3",
+	}
+
+	for name, source := range sources {
+		if actual := getoptlong.Source(name); actual != source {
+			t.Errorf("source of '%s' is '%s'. Expected '%s'.\n", name, actual, source)
+		}
+	}
+
+	if config := getoptlong.FormatConfig(configLongopts); config != expected {
+		t.Errorf("config is '%s'. Expected '%s'.\n", config, expected)
+	}
+}
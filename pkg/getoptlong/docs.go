@@ -0,0 +1,329 @@
+/*
+	@file      pkg/getoptlong/docs.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+type docOption struct {
+	anchor   string
+	spelling string
+	section  string
+	text     string
+	notes    []string
+}
+
+func anchor(text string) string {
+	return regexp.MustCompile("[^A-Za-z0-9_-]+").ReplaceAllString(text, "-")
+}
+
+func (e entry) key() string {
+	if e.long() != "" {
+		return "--" + e.long()
+	}
+
+	return fmt.Sprintf("-%c", e.short)
+}
+
+func (e entry) argument(code func(string) string) string {
+	switch e.hasArg() {
+	case RequiredArgument:
+		return "required " + code(e.argName())
+	case OptionalArgument:
+		return "optional " + code(e.argName())
+	case BooleanArgument:
+		return "boolean, negated by " + code("--"+OptNoPrefix+e.long())
+	}
+
+	return "none"
+}
+
+func groupNotes(cmd *Command, key string, code func(string) string) []string {
+	var notes []string
+
+	for _, group := range cmd.Groups {
+		var others []string
+		member := false
+
+		for _, option := range group.Options {
+			if lookupKey(cmd.Longopts, option) == key {
+				member = true
+			} else {
+				others = append(others, code(option))
+			}
+		}
+
+		if !member {
+			continue
+		}
+
+		switch group.Kind {
+		case MutuallyExclusive:
+			notes = append(notes, "Mutually exclusive with "+strings.Join(others, ", "))
+		case AllOrNone:
+			notes = append(notes, "Must be given together with "+strings.Join(others, ", "))
+		case AtLeastOne:
+			notes = append(notes, "At least one of "+strings.Join(append([]string{code(key)}, others...), ", ")+" is required")
+		}
+	}
+
+	return notes
+}
+
+/* Returns the options of the command grouped by Section like getoptlong.FormatHelp groups them. */
+func docOptions(cmd *Command, prefix string, code func(string) string) []docOption {
+	var sections []string
+	var options []docOption
+	bySection := map[string][]docOption{}
+
+	for _, e := range entries(withBuiltins(cmd.Shortopts, cmd.Longopts)) {
+		if e.hidden() {
+			continue
+		}
+
+		option := docOption{
+			anchor:   prefix + anchor(e.key()),
+			spelling: e.format(plain, plain),
+			section:  e.section(),
+			text:     e.description(),
+			notes:    []string{"Argument: " + e.argument(code)},
+		}
+
+		if e.option != nil && e.option.Default != "" {
+			option.notes = append(option.notes, "Default: "+code(e.option.Default))
+		}
+
+		if len(e.choices()) > 0 {
+			var choices []string
+
+			for _, choice := range e.choices() {
+				choices = append(choices, code(choice))
+			}
+
+			option.notes = append(option.notes, "Choices: "+strings.Join(choices, ", "))
+		}
+
+		if e.option != nil && e.option.Env != "" {
+			option.notes = append(option.notes, "Environment: "+code(e.option.Env))
+		}
+
+		if e.option != nil && e.option.Required {
+			option.notes = append(option.notes, "Required")
+		}
+
+		option.notes = append(option.notes, groupNotes(cmd, e.key(), code)...)
+
+		if _, ok := bySection[option.section]; !ok && option.section != "" {
+			sections = append(sections, option.section)
+		}
+
+		bySection[option.section] = append(bySection[option.section], option)
+	}
+
+	for _, section := range append([]string{""}, sections...) {
+		options = append(options, bySection[section]...)
+	}
+
+	return options
+}
+
+func markdownCode(text string) string {
+	return "`" + text + "`"
+}
+
+/*
+Escapes the characters of text Markdown would otherwise read as markup or HTML, and a '-' or '+'
+starting a line, which would start a list.
+*/
+func markdownText(text string) string {
+	var escaped strings.Builder
+
+	for i, c := range text {
+		switch {
+		case (c == '-' || c == '+') && (i == 0 || text[i-1] == '\n'):
+			escaped.WriteString("\\" + string(c))
+		case c == '<':
+			escaped.WriteString("&lt;")
+		case c == '>':
+			escaped.WriteString("&gt;")
+		case c == '&':
+			escaped.WriteString("&amp;")
+		case strings.ContainsRune("\\`*_[]#|~!", c):
+			escaped.WriteString("\\" + string(c))
+		default:
+			escaped.WriteRune(c)
+		}
+	}
+
+	return escaped.String()
+}
+
+/* Returns the prefix of a Markdown heading of level, which is at most 6. */
+func markdownHeading(level int) string {
+	return strings.Repeat("#", min(level, 6))
+}
+
+func writeMarkdown(doc *strings.Builder, cmd *Command, path string, id string, level int) {
+	fmt.Fprintf(doc, "<a id=\"%s\"></a>\n\n%s %s\n\n", id, markdownHeading(level), markdownText(path))
+
+	if cmd.Summary != "" {
+		fmt.Fprintf(doc, "%s\n\n", markdownText(cmd.Summary))
+	}
+
+	fmt.Fprintf(doc, "```\n%s```\n\n", FormatUsage(path, cmd.Shortopts, cmd.Longopts, 80, cmd.Operands...))
+
+	if cmd.Description != "" {
+		fmt.Fprintf(doc, "%s\n\n", markdownText(strings.TrimSpace(cmd.Description)))
+	}
+
+	if options := docOptions(cmd, id, markdownCode); len(options) > 0 {
+		section := ""
+
+		fmt.Fprintf(doc, "%s Options\n\n", markdownHeading(level+1))
+
+		for _, option := range options {
+			if option.section != section {
+				section = option.section
+				fmt.Fprintf(doc, "%s %s\n\n", markdownHeading(level+2), markdownText(section))
+			}
+
+			fmt.Fprintf(doc, "<a id=\"%s\"></a>\n\n%s `%s`\n\n", option.anchor, markdownHeading(level+3), option.spelling)
+
+			if option.text != "" {
+				fmt.Fprintf(doc, "%s\n\n", markdownText(option.text))
+			}
+
+			for _, note := range option.notes {
+				fmt.Fprintf(doc, "- %s\n", note)
+			}
+
+			doc.WriteString("\n")
+		}
+	}
+
+	if len(cmd.Commands) > 0 {
+		fmt.Fprintf(doc, "%s Commands\n\n", markdownHeading(level+1))
+
+		for _, sub := range cmd.Commands {
+			fmt.Fprintf(doc, "- [`%s`](#%s)", sub.Name, id+"."+anchor(sub.Name))
+
+			if sub.Summary != "" {
+				fmt.Fprintf(doc, ": %s", markdownText(sub.Summary))
+			}
+
+			doc.WriteString("\n")
+		}
+
+		doc.WriteString("\n")
+
+		for _, sub := range cmd.Commands {
+			writeMarkdown(doc, sub, path+" "+sub.Name, id+"."+anchor(sub.Name), level+1)
+		}
+	}
+}
+
+/*
+Formats a Markdown reference of the command and its subcommands. Every command and option is
+preceded by an anchor made of the command path and option, e.g. "prog.remote--verbose" or
+"prog.remote-v", which stays the same as long as neither is renamed.
+*/
+func FormatMarkdown(cmd *Command) string {
+	var doc strings.Builder
+
+	writeMarkdown(&doc, cmd, cmd.Name, anchor(cmd.Name), 1)
+
+	return strings.TrimSuffix(doc.String(), "\n")
+}
+
+func htmlCode(text string) string {
+	return "<code>" + html.EscapeString(text) + "</code>"
+}
+
+func writeHTML(doc *strings.Builder, cmd *Command, path string, id string, level int) {
+	heading := min(level, 4)
+
+	fmt.Fprintf(doc, "<section id=\"%s\">\n<h%d>%s</h%d>\n", id, heading, html.EscapeString(path), heading)
+
+	if cmd.Summary != "" {
+		fmt.Fprintf(doc, "<p>%s</p>\n", html.EscapeString(cmd.Summary))
+	}
+
+	fmt.Fprintf(doc, "<pre>%s</pre>\n", html.EscapeString(FormatUsage(path, cmd.Shortopts, cmd.Longopts, 80, cmd.Operands...)))
+
+	for _, paragraph := range strings.Split(strings.TrimSpace(cmd.Description), "\n\n") {
+		if paragraph != "" {
+			fmt.Fprintf(doc, "<p>%s</p>\n", html.EscapeString(strings.TrimSpace(paragraph)))
+		}
+	}
+
+	if options := docOptions(cmd, id, htmlCode); len(options) > 0 {
+		section := ""
+
+		fmt.Fprintf(doc, "<h%d>Options</h%d>\n<dl>\n", heading+1, heading+1)
+
+		for _, option := range options {
+			if option.section != section {
+				section = option.section
+				fmt.Fprintf(doc, "</dl>\n<h%d>%s</h%d>\n<dl>\n", heading+2, html.EscapeString(section), heading+2)
+			}
+
+			fmt.Fprintf(doc, "<dt id=\"%s\"><code>%s</code></dt>\n<dd>\n", option.anchor, html.EscapeString(option.spelling))
+
+			if option.text != "" {
+				fmt.Fprintf(doc, "<p>%s</p>\n", html.EscapeString(option.text))
+			}
+
+			doc.WriteString("<ul>\n")
+
+			for _, note := range option.notes {
+				fmt.Fprintf(doc, "<li>%s</li>\n", note)
+			}
+
+			doc.WriteString("</ul>\n</dd>\n")
+		}
+
+		doc.WriteString("</dl>\n")
+	}
+
+	if len(cmd.Commands) > 0 {
+		fmt.Fprintf(doc, "<h%d>Commands</h%d>\n<ul>\n", heading+1, heading+1)
+
+		for _, sub := range cmd.Commands {
+			fmt.Fprintf(doc, "<li><a href=\"#%s\"><code>%s</code></a>", id+"."+anchor(sub.Name), html.EscapeString(sub.Name))
+
+			if sub.Summary != "" {
+				fmt.Fprintf(doc, ": %s", html.EscapeString(sub.Summary))
+			}
+
+			doc.WriteString("</li>\n")
+		}
+
+		doc.WriteString("</ul>\n")
+
+		for _, sub := range cmd.Commands {
+			writeHTML(doc, sub, path+" "+sub.Name, id+"."+anchor(sub.Name), level+1)
+		}
+	}
+
+	doc.WriteString("</section>\n")
+}
+
+/* Formats a standalone HTML reference of the command and its subcommands, see getoptlong.FormatMarkdown. */
+func FormatHTML(cmd *Command) string {
+	var doc strings.Builder
+
+	doc.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&doc, "<title>%s</title>\n", html.EscapeString(cmd.Name))
+	doc.WriteString("</head>\n<body>\n")
+	writeHTML(&doc, cmd, cmd.Name, anchor(cmd.Name), 1)
+	doc.WriteString("</body>\n</html>\n")
+
+	return doc.String()
+}
@@ -0,0 +1,71 @@
+/*
+	@file      pkg/getoptlong/docs_test.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/BChristieDev/getopt_long.go/pkg/getoptlong"
+)
+
+var docsCommand = &getoptlong.Command{
+	Name:        "tool",
+	Summary:     "Manage <things>",
+	Description: "A tool for managing things.",
+	Shortopts:   "vC:",
+	Longopts: []getoptlong.Option{
+		{Name: "verbose", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'v', Description: "be verbose"},
+		{Name: "", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'C', ArgName: "DIR", Description: "run in DIR", Default: "."},
+		{Name: "json", HasArg: getoptlong.NoArgument, Flag: nil, Val: 0, Description: "print JSON", Section: "Output"},
+		{Name: "yaml", HasArg: getoptlong.NoArgument, Flag: nil, Val: 0, Description: "print YAML", Section: "Output"},
+		{Name: "color", HasArg: getoptlong.OptionalArgument, Flag: nil, Val: 0, Choices: []string{"auto", "never"}, Section: "Output"},
+		{Name: "quiet", HasArg: getoptlong.NoArgument, Flag: nil, Val: 0, Description: "print *nothing*"},
+		{Name: "debug", HasArg: getoptlong.NoArgument, Flag: nil, Val: 0, Hidden: true},
+	},
+	Groups: []getoptlong.Group{
+		{Kind: getoptlong.MutuallyExclusive, Options: []string{"--json", "--yaml"}},
+	},
+	Commands: []*getoptlong.Command{
+		{
+			Name:      "remote",
+			Summary:   "Manage remotes",
+			Shortopts: "f",
+			Longopts: []getoptlong.Option{
+				{Name: "fetch", HasArg: getoptlong.BooleanArgument, Flag: nil, Val: 'f', Description: "fetch after adding"},
+				{Name: "url", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 0, ArgName: "URL", Required: true},
+			},
+			Operands: []string{"name"},
+		},
+	},
+}
+
+func TestDocs(t *testing.T) {
+	t.Run("Markdown", func(t *testing.T) {
+		golden(t, "docs.md.golden", getoptlong.FormatMarkdown(docsCommand))
+	})
+
+	t.Run("HTML", func(t *testing.T) {
+		golden(t, "docs.html.golden", getoptlong.FormatHTML(docsCommand))
+	})
+
+	t.Run("Nesting", func(t *testing.T) {
+		cmd := &getoptlong.Command{Name: "a"}
+		leaf := cmd
+
+		for _, name := range []string{"b", "c", "d"} {
+			sub := &getoptlong.Command{Name: name}
+			leaf.Commands = []*getoptlong.Command{sub}
+			leaf = sub
+		}
+
+		leaf.Longopts = []getoptlong.Option{{Name: "x", HasArg: getoptlong.NoArgument, Flag: nil, Val: 0, Section: "More"}}
+
+		if doc := getoptlong.FormatMarkdown(cmd); strings.Contains(doc, "#######") || !strings.Contains(doc, "\n###### `--x`\n") {
+			t.Errorf("Markdown is '%s'. Expected headings of at most 6 levels.\n", doc)
+		}
+	})
+}
@@ -0,0 +1,155 @@
+/*
+	@file      pkg/getoptlong/entry.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/BChristieDev/getopt_long.go/internal/common"
+)
+
+type entry struct {
+	short    int
+	shortArg int
+	option   *Option
+}
+
+func entries(shortopts string, longopts []Option) []entry {
+	var result []entry
+	linked := map[int]bool{}
+
+	for i := 0; i < len(shortopts); i++ {
+		if shortopts[i] == ':' {
+			continue
+		}
+
+		e := entry{short: int(shortopts[i]), shortArg: NoArgument}
+
+		if common.CharAt(shortopts, i+1) == ":" && common.CharAt(shortopts, i+2) == ":" {
+			e.shortArg = OptionalArgument
+		} else if common.CharAt(shortopts, i+1) == ":" {
+			e.shortArg = RequiredArgument
+		}
+
+		if optarrind := findShortOpt(longopts, e.short); optarrind != -1 {
+			e.option = &longopts[optarrind]
+			linked[optarrind] = true
+		}
+
+		result = append(result, e)
+	}
+
+	for optarrind := range longopts {
+		if !linked[optarrind] && longopts[optarrind].Name != "" {
+			result = append(result, entry{option: &longopts[optarrind]})
+		}
+	}
+
+	return result
+}
+
+func (e entry) long() string {
+	if e.option == nil {
+		return ""
+	}
+
+	return e.option.Name
+}
+
+func (e entry) hasArg() int {
+	if e.long() == "" {
+		return e.shortArg
+	}
+
+	return e.option.HasArg
+}
+
+func (e entry) argName() string {
+	if e.option == nil || e.option.ArgName == "" {
+		return "ARG"
+	}
+
+	return e.option.ArgName
+}
+
+func (e entry) hidden() bool {
+	return e.option != nil && e.option.Hidden
+}
+
+func (e entry) description() string {
+	if e.option == nil {
+		return ""
+	}
+
+	return e.option.Description
+}
+
+func (e entry) section() string {
+	if e.option == nil {
+		return ""
+	}
+
+	return e.option.Section
+}
+
+func (e entry) notes() []string {
+	var notes []string
+
+	if e.option == nil {
+		return notes
+	}
+
+	if len(e.option.Choices) > 0 {
+		notes = append(notes, fmt.Sprintf("[choices: %s]", strings.Join(e.option.Choices, ", ")))
+	}
+
+	if e.option.Default != "" {
+		notes = append(notes, fmt.Sprintf("[default: %s]", e.option.Default))
+	}
+
+	if e.option.Env != "" {
+		notes = append(notes, fmt.Sprintf("[env: %s]", e.option.Env))
+	}
+
+	return notes
+}
+
+func (e entry) format(literal func(string) string, placeholder func(string) string) string {
+	var spelling string
+
+	if e.short != 0 {
+		spelling = literal(fmt.Sprintf("-%c", e.short))
+	}
+
+	if e.long() == "" {
+		switch e.shortArg {
+		case RequiredArgument:
+			spelling += " " + placeholder(e.argName())
+		case OptionalArgument:
+			spelling += "[" + placeholder(e.argName()) + "]"
+		}
+
+		return spelling
+	}
+
+	if spelling != "" {
+		spelling += ", "
+	}
+
+	switch e.option.HasArg {
+	case RequiredArgument:
+		spelling += literal("--"+e.long()) + "=" + placeholder(e.argName())
+	case OptionalArgument:
+		spelling += literal("--"+e.long()) + "[=" + placeholder(e.argName()) + "]"
+	case BooleanArgument:
+		spelling += literal("--") + "[" + literal(OptNoPrefix) + "]" + literal(e.long())
+	default:
+		spelling += literal("--" + e.long())
+	}
+
+	return spelling
+}
@@ -0,0 +1,125 @@
+/*
+	@file      pkg/getoptlong/env.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+/*
+Inserts the words of the environment variable name, split like a shell splits them, between argv[0]
+and argv[1], so that options given in argv are parsed after, and override, the ones of the
+environment, e.g. LESS="-R -i". The origins of the result are assigned to OptOrigin, "environment
+variable NAME" for the inserted words. The variable must only hold options and their arguments:
+getoptlong.Parse reports an operand or "--" of the variable, or an option at its end missing its
+argument, rather than letting them change how argv is parsed.
+*/
+func PrependEnv(argv []string, name string) ([]string, error) {
+	words, err := Split(os.Getenv(name), nil)
+
+	if err != nil {
+		return nil, fmt.Errorf("environment variable %s: %v", name, err)
+	}
+
+	if len(words) == 0 || len(argv) == 0 {
+		return argv, nil
+	}
+
+	origins := []string{origin(argv, 0)}
+	expanded := append([]string{argv[0]}, words...)
+
+	for range words {
+		origins = append(origins, "environment variable "+name)
+	}
+
+	for i := 1; i < len(argv); i++ {
+		origins = append(origins, origin(argv, i))
+	}
+
+	return withOrigins(append(expanded, argv[1:]...), origins), nil
+}
+
+/* Reports whether argv[index] was inserted from an environment variable by getoptlong.PrependEnv. */
+func fromEnv(argv []string, index int) bool {
+	return strings.HasPrefix(origin(argv, index), "environment variable ")
+}
+
+/*
+Reports whether argv[next], the element after the option at argv[index], is outside of the
+environment variable the option is from, and so cannot be its argument.
+*/
+func leavesEnv(argv []string, index int, next int) bool {
+	return fromEnv(argv, index) && next < len(argv) && origin(argv, next) != origin(argv, index)
+}
+
+/*
+Returns longopts[optarrind] as if it was given with value as its argument from origin, its Env or a
+config file, or false if it is an option without an argument and value is false.
+*/
+func parseFallbackOpt(progname string, longopts []Option, optarrind int, value string, origin string, indexptr *int) (int, bool) {
+	option := longopts[optarrind]
+	key := optionKey(longopts, optarrind)
+	errind, errorigin = -1, origin
+	OptArg = value
+	OptOpt = 0
+
+	if option.Name == "" {
+		OptOpt = option.Val
+	}
+
+	switch option.HasArg {
+	case NoArgument, BooleanArgument:
+		given, ok := parseBool(value, true)
+
+		if !ok {
+			return errChoice(progname, key, value, boolChoices, false), true
+		}
+
+		if !given && option.HasArg == NoArgument {
+			return 0, false
+		}
+
+		OptArg = formatBool(given)
+
+		if option.HasArg == NoArgument {
+			OptArg = ""
+		}
+	default:
+		if len(option.Choices) > 0 {
+			choice, ambiguous := matchChoice(option, value)
+
+			if choice == "" {
+				return errChoice(progname, key, value, option.Choices, ambiguous), true
+			}
+
+			OptArg = choice
+		}
+	}
+
+	record(key, Occurrence{Index: -1, Spelling: key, Arg: OptArg, Origin: errorigin})
+
+	if indexptr != nil {
+		*indexptr = optarrind
+	}
+
+	if option.Flag != nil && option.HasArg == BooleanArgument {
+		OptOpt = 0
+		*option.Flag = boolFlag(OptArg)
+
+		return 0, true
+	}
+
+	if option.Flag != nil {
+		OptOpt = 0
+		*option.Flag = option.Val
+
+		return 0, true
+	}
+
+	return option.Val, true
+}
@@ -0,0 +1,246 @@
+/*
+	@file      pkg/getoptlong/env_test.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/BChristieDev/getopt_long.go/pkg/getoptlong"
+)
+
+func TestPrependEnv(t *testing.T) {
+	longopts := []getoptlong.Option{
+		{Name: "color", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'c'},
+		{Name: "ignore-case", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'i'},
+	}
+
+	t.Run("Command line overrides the environment", func(t *testing.T) {
+		argv := []string{"prog", "--color=never", "file"}
+		expected := []string{"prog", "-i", "--color", "always on", "--color=never", "file"}
+		origins := []string{"", "environment variable PROG_OPTIONS", "environment variable PROG_OPTIONS", "environment variable PROG_OPTIONS", "", ""}
+		var color string
+
+		t.Setenv("PROG_OPTIONS", `-i --color "always on"`)
+		t.Cleanup(func() { cleanup(t) })
+
+		actual, err := getoptlong.PrependEnv(argv, "PROG_OPTIONS")
+
+		if err != nil || !slices.Equal(actual, expected) {
+			t.Errorf("argv is '%q' (%v). Expected '%q'.\n", actual, err, expected)
+		}
+
+		if !slices.Equal(getoptlong.OptOrigin, origins) {
+			t.Errorf("OptOrigin is '%q'. Expected '%q'.\n", getoptlong.OptOrigin, origins)
+		}
+
+		for opt := 0; opt != -1; opt = getoptlong.Parse(len(actual), actual, "c:i", longopts, nil) {
+			if opt == 'c' {
+				color = getoptlong.OptArg
+			}
+		}
+
+		if color != "never" {
+			t.Errorf("color is '%s'. Expected 'never'.\n", color)
+		}
+	})
+
+	t.Run("Unset variable", func(t *testing.T) {
+		argv := []string{"prog", "-i"}
+
+		t.Setenv("PROG_OPTIONS", "")
+		t.Cleanup(func() { cleanup(t) })
+
+		if actual, err := getoptlong.PrependEnv(argv, "PROG_OPTIONS"); err != nil || !slices.Equal(actual, argv) {
+			t.Errorf("argv is '%q' (%v). Expected '%q'.\n", actual, err, argv)
+		}
+	})
+
+	t.Run("Errors name the variable", func(t *testing.T) {
+		expected := "prog: unrecognized option '--colour' (from environment variable PROG_OPTIONS)\nDid you mean '--color'?"
+
+		t.Setenv("PROG_OPTIONS", "--colour=auto")
+		t.Cleanup(func() { cleanup(t) })
+
+		getoptlong.OptErr = 0
+		argv, _ := getoptlong.PrependEnv([]string{"prog", "-i"}, "PROG_OPTIONS")
+
+		if opt := getoptlong.Parse(len(argv), argv, "c:i", longopts, nil); opt != '?' {
+			t.Errorf("opt is '%c'. Expected '?'.\n", opt)
+		}
+
+		if getoptlong.LastError == nil || getoptlong.LastError.Error() != expected {
+			t.Errorf("LastError is '%v'. Expected '%s'.\n", getoptlong.LastError, expected)
+		}
+	})
+
+	t.Run("Words do not leak into argv", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			env      string
+			argv     []string
+			expected string
+			opts     []string
+		}{
+			{"Operand", "foo", []string{"prog", "-i", "file"}, "prog: unexpected 'foo' (from environment variable PROG_OPTIONS)", []string{"i "}},
+			{"Double dash", "--", []string{"prog", "-i", "file"}, "prog: unexpected '--' (from environment variable PROG_OPTIONS)", []string{"i "}},
+			{"Short option missing its argument", "-c", []string{"prog", "file"}, "prog: option requires an argument -- 'c' (from environment variable PROG_OPTIONS)", nil},
+			{"Long option missing its argument", "-i --color", []string{"prog", "file"}, "prog: option '--color' requires an argument (from environment variable PROG_OPTIONS)", []string{"i "}},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				var actual []string
+				var errors []string
+
+				t.Setenv("PROG_OPTIONS", test.env)
+				t.Cleanup(func() { cleanup(t) })
+
+				getoptlong.OptErr = 0
+				argv, _ := getoptlong.PrependEnv(test.argv, "PROG_OPTIONS")
+
+				for opt := getoptlong.Parse(len(argv), argv, "c:i", longopts, nil); opt != -1; opt = getoptlong.Parse(len(argv), argv, "c:i", longopts, nil) {
+					if getoptlong.LastError != nil {
+						errors = append(errors, getoptlong.LastError.Error())
+					} else {
+						actual = append(actual, fmt.Sprintf("%c %s", opt, getoptlong.OptArg))
+					}
+				}
+
+				if !slices.Equal(errors, []string{test.expected}) {
+					t.Errorf("errors are '%q'. Expected '%q'.\n", errors, []string{test.expected})
+				}
+
+				if !slices.Equal(actual, test.opts) {
+					t.Errorf("options are '%q'. Expected '%q'.\n", actual, test.opts)
+				}
+
+				if operands := argv[getoptlong.OptInd:]; !slices.Equal(operands, []string{"file"}) {
+					t.Errorf("operands are '%q'. Expected '%q'.\n", operands, []string{"file"})
+				}
+			})
+		}
+	})
+
+	t.Run("Syntax error", func(t *testing.T) {
+		expected := "environment variable PROG_OPTIONS: unterminated single quote at offset 3"
+
+		t.Setenv("PROG_OPTIONS", "-i 'x")
+
+		if _, err := getoptlong.PrependEnv([]string{"prog"}, "PROG_OPTIONS"); err == nil || err.Error() != expected {
+			t.Errorf("error is '%v'. Expected '%s'.\n", err, expected)
+		}
+	})
+}
+
+func TestEnvOption(t *testing.T) {
+	verbose := 0
+	longopts := []getoptlong.Option{
+		{Name: "listen", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'l', Env: "APP_LISTEN", Required: true},
+		{Name: "log-level", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 0, Env: "APP_LOG_LEVEL", Choices: []string{"debug", "info"}, ChoicePrefix: true},
+		{Name: "verbose", HasArg: getoptlong.NoArgument, Flag: &verbose, Val: 1, Env: "APP_VERBOSE"},
+	}
+
+	t.Run("Fallback", func(t *testing.T) {
+		args := []string{"prog", "--log-level=info"}
+		expected := []string{"0 info", fmt.Sprintf("%d :8080", 'l'), "0 "}
+		var actual []string
+		var longindex int
+
+		t.Setenv("APP_LISTEN", ":8080")
+		t.Setenv("APP_LOG_LEVEL", "debug")
+		t.Setenv("APP_VERBOSE", "yes")
+		t.Cleanup(func() { cleanup(t) })
+
+		for {
+			opt := getoptlong.Parse(len(args), args, "l:", longopts, &longindex)
+
+			if opt == -1 {
+				break
+			}
+
+			actual = append(actual, fmt.Sprintf("%d %s", opt, getoptlong.OptArg))
+		}
+
+		if !slices.Equal(actual, expected) {
+			t.Errorf("options are '%q'. Expected '%q'.\n", actual, expected)
+		}
+
+		if occurrences := getoptlong.Occurrences("-l"); len(occurrences) != 1 || occurrences[0].Index != -1 || occurrences[0].Origin != "environment variable APP_LISTEN" {
+			t.Errorf("occurrences of '-l' are '%+v'. Expected one from APP_LISTEN.\n", occurrences)
+		}
+
+		if verbose != 1 {
+			t.Errorf("verbose is '%d'. Expected '1'.\n", verbose)
+		}
+	})
+
+	t.Run("Command line takes precedence", func(t *testing.T) {
+		args := []string{"prog", "-l", ":9090"}
+
+		t.Setenv("APP_LISTEN", ":8080")
+		t.Cleanup(func() { cleanup(t) })
+
+		for getoptlong.Parse(len(args), args, "l:", longopts, nil) != -1 {
+		}
+
+		if value := getoptlong.Value("--listen"); value != ":9090" {
+			t.Errorf("listen is '%s'. Expected ':9090'.\n", value)
+		}
+	})
+
+	t.Run("Invalid value", func(t *testing.T) {
+		args := []string{"prog", "-l", ":9090"}
+		expected := "prog: invalid argument 'trace' for '--log-level' (from environment variable APP_LOG_LEVEL)\nValid arguments are:\n  - 'debug'\n  - 'info'"
+
+		t.Setenv("APP_LOG_LEVEL", "trace")
+		t.Cleanup(func() { cleanup(t) })
+
+		getoptlong.OptErr = 0
+
+		for getoptlong.Parse(len(args), args, "l:", longopts, nil) != '?' {
+		}
+
+		if getoptlong.LastError == nil || getoptlong.LastError.Error() != expected {
+			t.Errorf("LastError is '%v'. Expected '%s'.\n", getoptlong.LastError, expected)
+		}
+
+		for getoptlong.Parse(len(args), args, "l:", longopts, nil) != -1 {
+		}
+	})
+
+	t.Run("Mutually exclusive with the command line", func(t *testing.T) {
+		longopts := []getoptlong.Option{
+			{Name: "json", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'j', Env: "APP_JSON"},
+			{Name: "yaml", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'y'},
+		}
+		args := []string{"p", "--yaml"}
+		expected := []string{"y"}
+		var actual []string
+
+		t.Setenv("APP_JSON", "1")
+		t.Cleanup(func() { cleanup(t) })
+
+		getoptlong.OptGroups = []getoptlong.Group{{Kind: getoptlong.MutuallyExclusive, Options: []string{"--json", "--yaml"}}}
+
+		for opt := getoptlong.Parse(len(args), args, "", longopts, nil); opt != -1; opt = getoptlong.Parse(len(args), args, "", longopts, nil) {
+			actual = append(actual, string(rune(opt)))
+		}
+
+		if !slices.Equal(actual, expected) {
+			t.Errorf("options are '%q'. Expected '%q'.\n", actual, expected)
+		}
+	})
+
+	t.Run("Help", func(t *testing.T) {
+		expected := "  -l, --listen=ARG  [env: APP_LISTEN]\n"
+
+		if help := getoptlong.FormatHelp("l:", longopts[:1], 80); help != expected {
+			t.Errorf("help is '%s'. Expected '%s'.\n", help, expected)
+		}
+	})
+}
@@ -0,0 +1,45 @@
+/*
+	@file      pkg/getoptlong/error.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+import (
+	"strings"
+)
+
+/* An error encountered by getoptlong.Parse, see LastError. */
+type Error struct {
+	/* Diagnostic without suggestions, e.g. "prog: unrecognized option '--verbos'". */
+	Msg string
+	/* Options or commands suggested in place of an unrecognized one, e.g. "--verbose". */
+	Suggestions []string
+	/* Index of the argv element the error is in, or -1 if it is not in one, e.g. a missing option. */
+	Index int
+	/* Origin of the argv element the error is in, see OptOrigin. */
+	Origin string
+}
+
+/*
+Returns Msg with its Origin at the end of its first line, followed by a "Did you mean" line if there
+are Suggestions.
+*/
+func (err *Error) Error() string {
+	msg := err.Msg
+
+	if err.Origin != "" {
+		first, rest, multiline := strings.Cut(msg, "\n")
+		msg = first + " (from " + err.Origin + ")"
+
+		if multiline {
+			msg += "\n" + rest
+		}
+	}
+
+	if len(err.Suggestions) == 0 {
+		return msg
+	}
+
+	return msg + "\nDid you mean '" + strings.Join(err.Suggestions, "' or '") + "'?"
+}
@@ -14,23 +14,49 @@ import (
 	"github.com/BChristieDev/getopt_long.go/internal/common"
 )
 
+/*
+A long option, or the description of the short option Val when Name is empty. A short option in
+shortopts and a long option with a nil Flag and the same Val are the same option, e.g. -c and
+--create.
+*/
 type Option struct {
 	/* Name of the long option. */
 	Name string
 	/*
 		NoArgument (or 0) if the option does not take an argument;
-		RequiredArgument (or 1) if the option requires an argument; or
-		OptionalArgument (or 2) if the option takes an optional argument.
+		RequiredArgument (or 1) if the option requires an argument;
+		OptionalArgument (or 2) if the option takes an optional argument; or
+		BooleanArgument (or 3) if the option is a boolean that may be negated.
 	*/
 	HasArg int
 	/*
 		Specifies how results are returned for a long option. If Flag is not nil, then getoptlong.Parse
 		returns 0 and Val will be assigned to the integer Flag is pointing to, otherwise getoptlong.Parse
-		returns Val.
+		returns Val. For a BooleanArgument option BoolTrue or BoolFalse is assigned instead of Val.
 	*/
 	Flag *int
 	/* Value to return, or be assigned to the integer Flag is pointing to. */
 	Val int
+	/* Allowed values for the argument of the option; any value is allowed if empty. */
+	Choices []string
+	/* Accept an unambiguous prefix of one of Choices, OptArg is assigned the full choice. */
+	ChoicePrefix bool
+	/* Report an error once all options are parsed if the option was not given. */
+	Required bool
+	/* Value reported by getoptlong.Value if the option was not given. */
+	Default string
+	/* Description of the option shown by getoptlong.PrintHelp. */
+	Description string
+	/* Placeholder for the argument of the option shown by getoptlong.PrintHelp; default "ARG". */
+	ArgName string
+	/* Heading the option is listed under by getoptlong.PrintHelp. */
+	Section string
+	/* Omit the option from getoptlong.PrintHelp. */
+	Hidden bool
+	/* Environment variable used as the argument of the option if it was not given. */
+	Env string
+	/* Returns the candidates completing the argument arg of the option, see getoptlong.Complete. */
+	Complete func(arg string) []string
 }
 
 const (
@@ -40,6 +66,8 @@ const (
 	RequiredArgument = 1
 	/* An argument to the option may be presented */
 	OptionalArgument = 2
+	/* The option may be negated with OptNoPrefix, or given one of yes, no, true, false, 1 or 0 */
+	BooleanArgument = 3
 )
 
 var (
@@ -53,10 +81,26 @@ var (
 	OptOpt = 0
 	/* Resets parser's internal state */
 	OptReset = 0
-	nextchar = 0
+	/* Prefix negating a BooleanArgument long option; default "no-". */
+	OptNoPrefix = "no-"
+	/* Maximum edit distance of options suggested for an unrecognized option, set to 0 to suppress suggestions; default 2 */
+	OptSuggest = 2
+	/* Stores the error of the last call to getoptlong.Parse, or nil. */
+	LastError *Error = nil
+	nextchar         = 0
+	errind           = -1
+	errorigin        = ""
 )
 
-func errInvalidOpt(msg string, colon int) int {
+/* Sets the argv element, and its origin, the next error is in. */
+func errAt(argv []string, index int) {
+	errind = index
+	errorigin = origin(argv, index)
+}
+
+func errInvalidOpt(msg string, colon int, suggestions ...string) int {
+	LastError = &Error{Msg: msg, Suggestions: suggestions, Index: errind, Origin: errorigin}
+
 	if OptErr == 0 {
 		if colon == 1 {
 			return ':'
@@ -65,7 +109,7 @@ func errInvalidOpt(msg string, colon int) int {
 		return '?'
 	}
 
-	fmt.Fprintln(os.Stderr, msg)
+	fmt.Fprintln(os.Stderr, LastError.Error())
 
 	return '?'
 }
@@ -80,8 +124,13 @@ func parseArg(argv []string, hasArg int, optargind int) {
 	}
 }
 
+func findShortOpt(longopts []Option, opt int) int {
+	return common.FindIndex(longopts, func(longopt Option) bool { return longopt.Flag == nil && longopt.Val == opt })
+}
+
 func parseLongOpt(argc int, argv []string, longopts []Option, indexptr *int) int {
 	progname := filepath.Base(argv[0])
+	optind := OptInd
 	eq := common.IndexOf(argv[OptInd], "=", 3)
 	var opt string
 
@@ -92,20 +141,36 @@ func parseLongOpt(argc int, argv []string, longopts []Option, indexptr *int) int
 	}
 
 	optarrind := common.FindIndex(longopts, func(longopt Option) bool { return longopt.Name == opt })
+	negated := false
+
+	if optarrind == -1 && OptNoPrefix != "" && strings.HasPrefix(opt, OptNoPrefix) {
+		optarrind = common.FindIndex(longopts, func(longopt Option) bool {
+			return longopt.Name != "" && longopt.Name == opt[len(OptNoPrefix):] && longopt.HasArg == BooleanArgument
+		})
+		negated = optarrind != -1
+	}
 
 	if optarrind == -1 {
 		OptOpt = 0
 		OptInd++
 
-		return errInvalidOpt(fmt.Sprintf("%s: unrecognized option '--%s'", progname, opt), 0)
+		return errInvalidOpt(fmt.Sprintf("%s: unrecognized option '--%s'", progname, opt), 0, suggestLongOpt(longopts, opt)...)
 	}
 
 	if indexptr != nil {
 		*indexptr = optarrind
 	}
 
+	hasArg := longopts[optarrind].HasArg
+
+	if hasArg == BooleanArgument && negated {
+		hasArg = NoArgument
+	} else if hasArg == BooleanArgument {
+		hasArg = OptionalArgument
+	}
+
 	if eq >= 0 {
-		if longopts[optarrind].HasArg <= NoArgument || longopts[optarrind].HasArg > OptionalArgument {
+		if hasArg <= NoArgument || hasArg > OptionalArgument {
 			OptOpt = 0
 			OptInd++
 
@@ -115,13 +180,46 @@ func parseLongOpt(argc int, argv []string, longopts []Option, indexptr *int) int
 		OptInd++
 	}
 
-	if longopts[optarrind].HasArg == RequiredArgument && OptInd >= argc {
+	if hasArg == RequiredArgument && (OptInd >= argc || (eq == -1 && leavesEnv(argv, optind, OptInd))) {
 		OptOpt = 0
 
 		return errInvalidOpt(fmt.Sprintf("%s: option '--%s' requires an argument", progname, opt), 1)
 	}
 
-	parseArg(argv, longopts[optarrind].HasArg, eq+1)
+	parseArg(argv, hasArg, eq+1)
+
+	if longopts[optarrind].HasArg == BooleanArgument {
+		value, ok := parseBool(OptArg, !negated)
+
+		if !ok {
+			OptOpt = 0
+
+			return errChoice(progname, "--"+opt, OptArg, boolChoices, false)
+		}
+
+		OptArg = formatBool(value)
+	}
+
+	if len(longopts[optarrind].Choices) > 0 && (hasArg == RequiredArgument || OptArg != "") {
+		choice, ambiguous := matchChoice(longopts[optarrind], OptArg)
+
+		if choice == "" {
+			OptOpt = 0
+
+			return errChoice(progname, "--"+opt, OptArg, longopts[optarrind].Choices, ambiguous)
+		}
+
+		OptArg = choice
+	}
+
+	record(optionKey(longopts, optarrind), Occurrence{Index: optind, Spelling: "--" + opt, Arg: OptArg, Origin: origin(argv, optind)})
+
+	if longopts[optarrind].Flag != nil && longopts[optarrind].HasArg == BooleanArgument {
+		OptOpt = 0
+		*longopts[optarrind].Flag = boolFlag(OptArg)
+
+		return 0
+	}
 
 	if longopts[optarrind].Flag != nil {
 		OptOpt = 0
@@ -133,18 +231,20 @@ func parseLongOpt(argc int, argv []string, longopts []Option, indexptr *int) int
 	return longopts[optarrind].Val
 }
 
-func parseShortOpt(argc int, argv []string, shortopts string) int {
+func parseShortOpt(argc int, argv []string, shortopts string, longopts []Option) int {
 	progname := filepath.Base(argv[0])
+	optind := OptInd
 	opt := int(argv[OptInd][nextchar])
 	optstrind := strings.Index(shortopts, argv[OptInd][nextchar:nextchar+1])
 	hasArg := NoArgument
 
 	if optstrind == -1 {
+		suggestions := suggestShortOpt(shortopts, longopts, argv[OptInd], nextchar)
 		OptOpt = opt
 		OptInd++
 		nextchar = 0
 
-		return errInvalidOpt(fmt.Sprintf("%s: invalid option -- '%c'", progname, opt), 0)
+		return errInvalidOpt(fmt.Sprintf("%s: invalid option -- '%c'", progname, opt), 0, suggestions...)
 	}
 
 	nextchar++
@@ -160,7 +260,7 @@ func parseShortOpt(argc int, argv []string, shortopts string) int {
 		hasArg = RequiredArgument
 	}
 
-	if hasArg == RequiredArgument && OptInd >= argc {
+	if hasArg == RequiredArgument && (OptInd >= argc || (nextchar == 0 && leavesEnv(argv, optind, OptInd))) {
 		OptOpt = opt
 
 		return errInvalidOpt(fmt.Sprintf("%s: option requires an argument -- '%c'", progname, opt), 1)
@@ -168,6 +268,32 @@ func parseShortOpt(argc int, argv []string, shortopts string) int {
 
 	parseArg(argv, hasArg, nextchar)
 
+	optarrind := findShortOpt(longopts, opt)
+
+	if optarrind != -1 && longopts[optarrind].HasArg == BooleanArgument && hasArg == NoArgument {
+		OptArg = formatBool(true)
+	}
+
+	if optarrind != -1 && len(longopts[optarrind].Choices) > 0 && (hasArg == RequiredArgument || OptArg != "") {
+		choice, ambiguous := matchChoice(longopts[optarrind], OptArg)
+
+		if choice == "" {
+			OptOpt = opt
+
+			return errChoice(progname, fmt.Sprintf("-%c", opt), OptArg, longopts[optarrind].Choices, ambiguous)
+		}
+
+		OptArg = choice
+	}
+
+	occurrence := Occurrence{Index: optind, Spelling: fmt.Sprintf("-%c", opt), Arg: OptArg, Origin: origin(argv, optind)}
+
+	if optarrind == -1 {
+		record(occurrence.Spelling, occurrence)
+	} else {
+		record(optionKey(longopts, optarrind), occurrence)
+	}
+
 	return opt
 }
 
@@ -178,13 +304,43 @@ is pointing to. If indexptr is not nil, then the index of the long option in lon
 the integer indexptr is pointing to.
 
 If an unrecognized option is encountered '?' is returned. If an option with a missing argument is
-encountered '?' is returned with OptErr is is non-zero, otherwise ':' is returned.
+encountered '?' is returned with OptErr is is non-zero, otherwise ':' is returned. LastError
+describes the error, along with the options suggested in place of an unrecognized option and the
+origin of the element, see OptOrigin. An operand or "--" inserted by getoptlong.PrependEnv returns
+'?' too, and an option at the end of the environment variable is missing its argument rather than
+taking it from the rest of argv.
+
+If all options are parsed, each option that was not given is returned as if it was given with the
+value of its Env as its argument if the environment variable is set, or else with its value in
+OptConfig. The value of an option without an argument is a boolean, e.g. 1 or true to give the
+option, and 0 or false not to. An invalid value returns '?', and LastError names the variable or
+config file line as its origin. The argument of an option is thus taken from argv, then its Env,
+then OptConfig, and then its Default, see getoptlong.Value and getoptlong.Source. An option of a
+MutuallyExclusive group of OptGroups is not taken from its Env or OptConfig if another option of the
+group was given in argv.
+
+If all options are parsed and a required option was not given '?' is returned, once for each
+missing option.
+
+If an option of OptBuiltins is encountered HelpRequested or VersionRequested is returned, and the
+next call returns -1 without parsing the remaining arguments or checking the options given. The
+built-in options follow longopts, so the index assigned to indexptr for one is len(longopts) or more.
 
 If all options are parsed -1 is returned.
 */
 func Parse(argc int, argv []string, shortopts string, longopts []Option, indexptr *int) int {
-	if OptInd >= argc {
-		return -1
+	shortopts, longopts = withBuiltins(shortopts, longopts)
+
+	if common.CharAt(shortopts, 0) == ":" {
+		OptErr = 0
+	}
+
+	LastError = nil
+	specLongopts = longopts
+	errAt(nil, -1)
+
+	if ending {
+		return finish(argv, longopts, indexptr)
 	}
 
 	if OptInd == 0 {
@@ -192,31 +348,48 @@ func Parse(argc int, argv []string, shortopts string, longopts []Option, indexpt
 		OptReset = 1
 	}
 
-	if OptReset == 1 {
+	if OptReset == 1 || (OptInd == 1 && nextchar == 0) {
 		OptReset = 0
 		nextchar = 0
+		reset()
 	}
 
-	if common.CharAt(shortopts, 0) == ":" {
-		OptErr = 0
+	if stopped {
+		return -1
+	}
+
+	if OptInd >= argc {
+		return finish(argv, longopts, indexptr)
 	}
 
 	if nextchar == 0 {
+		errAt(argv, OptInd)
+
+		if fromEnv(argv, OptInd) && (common.CharAt(argv[OptInd], 0) != "-" || argv[OptInd] == "-" || argv[OptInd] == "--") {
+			OptOpt = 0
+			OptInd++
+
+			return errInvalidOpt(fmt.Sprintf("%s: unexpected '%s'", filepath.Base(argv[0]), argv[OptInd-1]), 0)
+		}
+
 		if common.CharAt(argv[OptInd], 0) != "-" || argv[OptInd] == "-" {
-			return -1
+			return finish(argv, longopts, indexptr)
 		}
 
 		if argv[OptInd] == "--" {
 			OptInd++
-			return -1
+			dashdash = true
+			return finish(argv, longopts, indexptr)
 		}
 
 		if common.CharAt(argv[OptInd], 1) == "-" {
-			return parseLongOpt(argc, argv, longopts, indexptr)
+			return builtinResult(parseLongOpt(argc, argv, longopts, indexptr))
 		}
 
 		nextchar++
 	}
 
-	return parseShortOpt(argc, argv, shortopts)
+	errAt(argv, OptInd)
+
+	return builtinResult(parseShortOpt(argc, argv, shortopts, longopts))
 }
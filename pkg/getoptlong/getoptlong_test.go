@@ -22,6 +22,12 @@ func cleanup(t *testing.T) {
 	getoptlong.OptInd = 1
 	getoptlong.OptErr = 1
 	getoptlong.OptOpt = 0
+	getoptlong.OptGroups = nil
+	getoptlong.OptNoPrefix = "no-"
+	getoptlong.OptSuggest = 2
+	getoptlong.OptBuiltins = 0
+	getoptlong.OptOrigin = nil
+	getoptlong.OptConfig = map[string]getoptlong.ConfigValue{}
 }
 
 func TestLongOptions(t *testing.T) {
@@ -0,0 +1,141 @@
+/*
+	@file      pkg/getoptlong/help.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	helpIndent = 2
+	helpColumn = 30
+)
+
+func plain(text string) string {
+	return text
+}
+
+func terminalWidth() int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+
+	if columns := ttyWidth(os.Stdout); columns > 0 {
+		return columns
+	}
+
+	return 80
+}
+
+func wrap(text string, width int) []string {
+	var lines []string
+	var line string
+
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+
+		if line != "" {
+			line += " "
+		}
+
+		line += word
+	}
+
+	if line != "" {
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+func helpSpelling(e entry) string {
+	if e.short == 0 {
+		return "    " + e.format(plain, plain)
+	}
+
+	return e.format(plain, plain)
+}
+
+/*
+Formats the options as GNU style --help output. Options are listed in the order of shortopts
+followed by the long options without a short option, grouped by Section, with descriptions aligned
+and wrapped to width columns.
+*/
+func FormatHelp(shortopts string, longopts []Option, width int) string {
+	shortopts, longopts = withBuiltins(shortopts, longopts)
+
+	return formatHelp(shortopts, longopts, width)
+}
+
+func formatHelp(shortopts string, longopts []Option, width int) string {
+	var sections []string
+	var help strings.Builder
+	bySection := map[string][]entry{}
+	column := 0
+
+	for _, e := range entries(shortopts, longopts) {
+		if e.hidden() {
+			continue
+		}
+
+		if _, ok := bySection[e.section()]; !ok && e.section() != "" {
+			sections = append(sections, e.section())
+		}
+
+		bySection[e.section()] = append(bySection[e.section()], e)
+		column = max(column, helpIndent+len(helpSpelling(e))+2)
+	}
+
+	column = min(column, helpColumn)
+
+	for index, section := range append([]string{""}, sections...) {
+		if section != "" {
+			if index > 1 || len(bySection[""]) > 0 {
+				help.WriteString("\n")
+			}
+
+			help.WriteString(section + ":\n")
+		}
+
+		for _, e := range bySection[section] {
+			left := strings.Repeat(" ", helpIndent) + helpSpelling(e)
+			description := strings.Join(append([]string{e.description()}, e.notes()...), " ")
+			lines := wrap(description, max(width-column, 20))
+
+			if len(lines) == 0 {
+				help.WriteString(left + "\n")
+				continue
+			}
+
+			if len(left)+2 > column {
+				help.WriteString(left + "\n")
+			} else {
+				lines[0] = left + strings.Repeat(" ", column-len(left)) + lines[0]
+			}
+
+			for i, line := range lines {
+				if i > 0 || len(left)+2 > column {
+					line = strings.Repeat(" ", column) + line
+				}
+
+				help.WriteString(line + "\n")
+			}
+		}
+	}
+
+	return help.String()
+}
+
+/* Writes getoptlong.FormatHelp to w, wrapped to the width of the terminal. */
+func PrintHelp(w io.Writer, shortopts string, longopts []Option) {
+	io.WriteString(w, FormatHelp(shortopts, longopts, terminalWidth()))
+}
@@ -0,0 +1,67 @@
+/*
+	@file      pkg/getoptlong/help_test.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong_test
+
+import (
+	"testing"
+
+	"github.com/BChristieDev/getopt_long.go/pkg/getoptlong"
+)
+
+func TestHelp(t *testing.T) {
+	t.Run("Short and long options share a line", func(t *testing.T) {
+		longopts := []getoptlong.Option{
+			{Name: "create", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'c', ArgName: "NAME", Description: "create NAME"},
+			{Name: "", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'd', ArgName: "DIR", Description: "change to DIR"},
+			{Name: "bar", HasArg: getoptlong.OptionalArgument, Flag: nil, Val: 0, Description: "maybe bar"},
+			{Name: "frob", HasArg: getoptlong.BooleanArgument, Flag: nil, Val: 0, Description: "frobnicate"},
+		}
+		expected := "" +
+			"  -a\n" +
+			"  -c, --create=NAME  create NAME\n" +
+			"  -d DIR             change to DIR\n" +
+			"      --bar[=ARG]    maybe bar\n" +
+			"      --[no-]frob    frobnicate\n"
+
+		if help := getoptlong.FormatHelp("ac:d:", longopts, 80); help != expected {
+			t.Errorf("help is '%s'. Expected '%s'.\n", help, expected)
+		}
+	})
+
+	t.Run("Wrapping", func(t *testing.T) {
+		longopts := []getoptlong.Option{
+			{Name: "color", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 0, Description: "colorize the output", Choices: []string{"auto", "never"}, Default: "auto"},
+			{Name: "a-very-long-option-name", HasArg: getoptlong.NoArgument, Flag: nil, Val: 0, Description: "long"},
+		}
+		expected := "" +
+			"      --color=ARG             colorize the output\n" +
+			"                              [choices: auto, never]\n" +
+			"                              [default: auto]\n" +
+			"      --a-very-long-option-name\n" +
+			"                              long\n"
+
+		if help := getoptlong.FormatHelp("", longopts, 52); help != expected {
+			t.Errorf("help is '%s'. Expected '%s'.\n", help, expected)
+		}
+	})
+
+	t.Run("Sections and hidden options", func(t *testing.T) {
+		longopts := []getoptlong.Option{
+			{Name: "verbose", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'v', Description: "be verbose"},
+			{Name: "json", HasArg: getoptlong.NoArgument, Flag: nil, Val: 0, Description: "print JSON", Section: "Output"},
+			{Name: "debug", HasArg: getoptlong.NoArgument, Flag: nil, Val: 0, Hidden: true},
+		}
+		expected := "" +
+			"  -v, --verbose  be verbose\n" +
+			"\n" +
+			"Output:\n" +
+			"      --json     print JSON\n"
+
+		if help := getoptlong.FormatHelp("v", longopts, 80); help != expected {
+			t.Errorf("help is '%s'. Expected '%s'.\n", help, expected)
+		}
+	})
+}
@@ -0,0 +1,131 @@
+/*
+	@file      pkg/getoptlong/man.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+import (
+	"fmt"
+	"strings"
+)
+
+type ManPage struct {
+	/* Name of the program. */
+	Name string
+	/* Section of the manual; default "1". */
+	Section string
+	/* Date of the last change, left empty unless given to keep the output deterministic. */
+	Date string
+	/* Source of the program, e.g. "getopt_long.go 1.0.0". */
+	Source string
+	/* Title of the manual, e.g. "User Commands". */
+	Manual string
+	/* One line summary shown in the NAME section. */
+	Summary string
+	/* Paragraphs of the DESCRIPTION section, separated by blank lines. */
+	Description string
+	/* Operands shown after the options in the SYNOPSIS section, e.g. "file ...". */
+	Operands []string
+}
+
+func roff(text string) string {
+	text = strings.ReplaceAll(text, "\\", "\\e")
+	text = strings.ReplaceAll(text, "-", "\\-")
+
+	if strings.HasPrefix(text, ".") || strings.HasPrefix(text, "'") {
+		text = "\\&" + text
+	}
+
+	return text
+}
+
+func roffBold(text string) string {
+	return "\\fB" + roff(text) + "\\fR"
+}
+
+func roffItalic(text string) string {
+	return "\\fI" + roff(text) + "\\fR"
+}
+
+func roffQuote(text string) string {
+	return "\"" + strings.ReplaceAll(roff(text), "\"", "\\(dq") + "\""
+}
+
+/*
+Formats a man(7) page with NAME, SYNOPSIS, DESCRIPTION and OPTIONS sections from the options, which
+are listed like getoptlong.FormatHelp lists them.
+*/
+func FormatManPage(page ManPage, shortopts string, longopts []Option) string {
+	shortopts, longopts = withBuiltins(shortopts, longopts)
+
+	var man strings.Builder
+	var sections []string
+	bySection := map[string][]entry{}
+	section := page.Section
+
+	if section == "" {
+		section = "1"
+	}
+
+	fmt.Fprintf(&man, ".TH %s %s %s %s %s\n", roff(strings.ToUpper(page.Name)), roff(section), roffQuote(page.Date), roffQuote(page.Source), roffQuote(page.Manual))
+	man.WriteString(".SH NAME\n")
+	fmt.Fprintf(&man, "%s \\- %s\n", roff(page.Name), roff(page.Summary))
+	man.WriteString(".SH SYNOPSIS\n")
+	fmt.Fprintf(&man, ".B %s\n", roff(page.Name))
+
+	for _, item := range synopsis(shortopts, longopts, roffBold, roffItalic) {
+		man.WriteString(item + "\n")
+	}
+
+	for _, operand := range page.Operands {
+		man.WriteString(roff(operand) + "\n")
+	}
+
+	if page.Description != "" {
+		man.WriteString(".SH DESCRIPTION\n")
+
+		for index, paragraph := range strings.Split(strings.TrimSpace(page.Description), "\n\n") {
+			if index > 0 {
+				man.WriteString(".PP\n")
+			}
+
+			for _, line := range strings.Split(strings.TrimSpace(paragraph), "\n") {
+				man.WriteString(roff(strings.TrimSpace(line)) + "\n")
+			}
+		}
+	}
+
+	for _, e := range entries(shortopts, longopts) {
+		if e.hidden() {
+			continue
+		}
+
+		if _, ok := bySection[e.section()]; !ok && e.section() != "" {
+			sections = append(sections, e.section())
+		}
+
+		bySection[e.section()] = append(bySection[e.section()], e)
+	}
+
+	if len(bySection) > 0 {
+		man.WriteString(".SH OPTIONS\n")
+	}
+
+	for _, section := range append([]string{""}, sections...) {
+		if section != "" {
+			fmt.Fprintf(&man, ".SS %s\n", roff(section))
+		}
+
+		for _, e := range bySection[section] {
+			man.WriteString(".TP\n")
+			man.WriteString(e.format(roffBold, roffItalic) + "\n")
+
+			if description := strings.Join(append([]string{e.description()}, e.notes()...), " "); strings.TrimSpace(description) != "" {
+				man.WriteString(roff(strings.TrimSpace(description)) + "\n")
+			}
+		}
+	}
+
+	return man.String()
+}
@@ -0,0 +1,63 @@
+/*
+	@file      pkg/getoptlong/man_test.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BChristieDev/getopt_long.go/pkg/getoptlong"
+)
+
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+func golden(t *testing.T, name string, actual string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+
+	if *update {
+		if err := os.WriteFile(path, []byte(actual), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected, err := os.ReadFile(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if actual != string(expected) {
+		t.Errorf("%s is '%s'. Expected '%s'.\n", name, actual, expected)
+	}
+}
+
+func TestManPage(t *testing.T) {
+	t.Run("Golden", func(t *testing.T) {
+		page := getoptlong.ManPage{
+			Name:        "man-page-example",
+			Date:        "2024-01-01",
+			Source:      "getopt_long.go",
+			Manual:      "User Commands",
+			Summary:     "demonstrate getopt_long",
+			Description: "Parses the options of getopt_long(3).\n\n.Lines starting with a dot are escaped.",
+			Operands:    []string{"file ..."},
+		}
+		longopts := []getoptlong.Option{
+			{Name: "add", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 0, Description: "add an entry"},
+			{Name: "bar", HasArg: getoptlong.OptionalArgument, Flag: nil, Val: 0, Description: "maybe bar"},
+			{Name: "verbose", HasArg: getoptlong.BooleanArgument, Flag: nil, Val: 0, Description: "be verbose", Section: "Output"},
+			{Name: "create", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'c', ArgName: "NAME", Description: "create NAME", Choices: []string{"a", "b"}},
+			{Name: "", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'a', Description: "option a"},
+			{Name: "debug", HasArg: getoptlong.NoArgument, Flag: nil, Val: 0, Hidden: true},
+		}
+
+		golden(t, "man.golden", getoptlong.FormatManPage(page, "abc:d::", longopts))
+	})
+}
@@ -0,0 +1,136 @@
+/*
+	@file      pkg/getoptlong/plugin.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+/* Prefix of the executables of the plugins of the last command of path, e.g. "tool-remote-". */
+func pluginPrefix(path []*Command) string {
+	return strings.ReplaceAll(commandPath(path), " ", "-") + "-"
+}
+
+/* Name of the environment variable forwarding the option key, e.g. TOOL_OPT_DRY_RUN for --dry-run. */
+func pluginEnvName(root *Command, key string) string {
+	name := strings.TrimLeft(key, "-")
+
+	if strings.HasPrefix(key, "--") {
+		name = strings.ToUpper(name)
+	}
+
+	return regexp.MustCompile("[^A-Za-z0-9]").ReplaceAllString(strings.ToUpper(root.Name)+"_OPT_"+name, "_")
+}
+
+/*
+Returns the environment forwarding the options given so far to a plugin, the number of times it was
+given for an option without an argument, and its last argument otherwise.
+*/
+func pluginEnv(root *Command, merged *commandOptions) []string {
+	var env []string
+
+	for _, e := range entries(merged.shortopts, merged.longopts) {
+		given := occurrences[e.key()]
+
+		if len(given) == 0 {
+			continue
+		}
+
+		value := given[len(given)-1].Arg
+
+		if e.hasArg() == NoArgument {
+			value = strconv.Itoa(len(given))
+		}
+
+		env = append(env, pluginEnvName(root, e.key())+"="+value)
+	}
+
+	return env
+}
+
+/*
+Returns the names of the plugins of the last command of path found on PATH, sorted and without the
+names of its subcommands.
+*/
+func Plugins(path []*Command) []string {
+	var names []string
+	prefix := pluginPrefix(path)
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		files, _ := os.ReadDir(dir)
+
+		for _, file := range files {
+			name := file.Name()
+
+			if runtime.GOOS == "windows" {
+				name = strings.TrimSuffix(name, filepath.Ext(name))
+			}
+
+			name, ok := strings.CutPrefix(name, prefix)
+
+			if !ok || name == "" || slices.Contains(names, name) || findCommandName(path[len(path)-1].Commands, name) {
+				continue
+			}
+
+			if _, err := exec.LookPath(filepath.Join(dir, file.Name())); err == nil {
+				names = append(names, name)
+			}
+		}
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+func findCommandName(commands []*Command, name string) bool {
+	return slices.ContainsFunc(commands, func(cmd *Command) bool { return cmd.Name == name })
+}
+
+/* Returns the executable of the plugin name of the last command of path, or false if there is none on PATH. */
+func lookPlugin(path []*Command, name string) (string, bool) {
+	if strings.ContainsAny(name, "/"+string(filepath.Separator)) {
+		return "", false
+	}
+
+	executable, err := exec.LookPath(pluginPrefix(path) + name)
+
+	return executable, err == nil
+}
+
+/*
+Runs the plugin executable of the last command of path with args, forwarding the options given so far
+in its environment, and returns its exit status.
+*/
+func runPlugin(path []*Command, merged *commandOptions, executable string, name string, args []string) int {
+	plugin := exec.Command(executable, args...)
+	plugin.Args[0] = pluginPrefix(path) + name
+	plugin.Env = append(os.Environ(), pluginEnv(path[0], merged)...)
+	plugin.Stdin, plugin.Stdout, plugin.Stderr = os.Stdin, os.Stdout, os.Stderr
+
+	if err := plugin.Run(); err != nil {
+		var exitErr *exec.ExitError
+
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode()
+		}
+
+		fmt.Fprintf(os.Stderr, "%s: %v\n", plugin.Args[0], err)
+
+		return 126
+	}
+
+	return 0
+}
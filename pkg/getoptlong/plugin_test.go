@@ -0,0 +1,114 @@
+/*
+	@file      pkg/getoptlong/plugin_test.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/BChristieDev/getopt_long.go/pkg/getoptlong"
+)
+
+/* Writes a plugin to dir writing its name, arguments and forwarded options to out with shell built-ins. */
+func writePlugin(t *testing.T, dir string, name string, out string, status int) {
+	t.Helper()
+
+	script := "#!/bin/sh\n" +
+		"{ echo \"${0##*/} $*\"; echo \"C=$TOOL_OPT_C DRY_RUN=$TOOL_OPT_DRY_RUN VERBOSE=$TOOL_OPT_VERBOSE\"; } > '" + out + "'\n" +
+		"exit " + strconv.Itoa(status) + "\n"
+
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts")
+	}
+
+	dir := t.TempDir()
+	out := filepath.Join(t.TempDir(), "out")
+	root := &getoptlong.Command{
+		Name:           "tool",
+		Shortopts:      "vC:",
+		Plugins:        true,
+		CommandSuggest: 2,
+		Longopts: []getoptlong.Option{
+			{Name: "verbose", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'v'},
+			{Name: "", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'C'},
+			{Name: "dry-run", HasArg: getoptlong.NoArgument, Flag: nil, Val: 0},
+		},
+		Commands: []*getoptlong.Command{{Name: "log", Run: func(operands []string) int { return 0 }}},
+	}
+
+	writePlugin(t, dir, "tool-foo", out, 3)
+	writePlugin(t, dir, "tool-log", out, 0)
+	os.WriteFile(filepath.Join(dir, "tool-data"), nil, 0o644)
+	t.Setenv("PATH", dir)
+
+	t.Run("Discovery", func(t *testing.T) {
+		if plugins := getoptlong.Plugins([]*getoptlong.Command{root}); !slices.Equal(plugins, []string{"foo"}) {
+			t.Errorf("plugins are '%v'. Expected '[foo]'.\n", plugins)
+		}
+	})
+
+	t.Run("Dispatch", func(t *testing.T) {
+		args := []string{"tool", "-vv", "-C", "dir", "--dry-run", "foo", "--bar", "baz"}
+		expected := "tool-foo --bar baz\nC=dir DRY_RUN=1 VERBOSE=2\n"
+
+		t.Cleanup(func() { cleanup(t) })
+
+		if status := getoptlong.Execute(root, args); status != 3 {
+			t.Errorf("status is '%d'. Expected '3'.\n", status)
+		}
+
+		if actual, _ := os.ReadFile(out); string(actual) != expected {
+			t.Errorf("plugin output is '%s'. Expected '%s'.\n", actual, expected)
+		}
+	})
+
+	t.Run("Subcommands take precedence", func(t *testing.T) {
+		os.Remove(out)
+
+		t.Cleanup(func() { cleanup(t) })
+
+		if status := getoptlong.Execute(root, []string{"tool", "log"}); status != 0 {
+			t.Errorf("status is '%d'. Expected '0'.\n", status)
+		}
+
+		if _, err := os.Stat(out); err == nil {
+			t.Errorf("plugin 'tool-log' was run. Expected subcommand 'log'.\n")
+		}
+	})
+
+	t.Run("Suggestion", func(t *testing.T) {
+		t.Cleanup(func() { cleanup(t) })
+
+		getoptlong.OptErr = 0
+
+		if status := getoptlong.Execute(root, []string{"tool", "fo"}); status != 2 {
+			t.Errorf("status is '%d'. Expected '2'.\n", status)
+		}
+
+		if getoptlong.LastError == nil || !slices.Equal(getoptlong.LastError.Suggestions, []string{"foo"}) {
+			t.Errorf("LastError is '%v'. Expected suggestion 'foo'.\n", getoptlong.LastError)
+		}
+	})
+
+	t.Run("Help", func(t *testing.T) {
+		help := getoptlong.FormatCommandHelp([]*getoptlong.Command{root}, 80)
+
+		if !strings.HasSuffix(help, "\nPlugins:\n  foo\n") {
+			t.Errorf("help is '%s'. Expected it to list plugin 'foo'.\n", help)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+/*
+	@file      pkg/getoptlong/record.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+import (
+	"fmt"
+)
+
+type Occurrence struct {
+	/* Index of the argv element the option was given in, or -1 if it was not given in argv. */
+	Index int
+	/* Option as it was typed, e.g. "--output" or "-o". */
+	Spelling string
+	/* Argument of the option, or "" if it was given without one. */
+	Arg string
+	/* Origin of the option, see OptOrigin, e.g. "environment variable APP_LISTEN" for an Env option. */
+	Origin string
+}
+
+var (
+	occurrences  = map[string][]Occurrence{}
+	specLongopts []Option
+)
+
+func optionKey(longopts []Option, optarrind int) string {
+	if longopts[optarrind].Name == "" {
+		return fmt.Sprintf("-%c", longopts[optarrind].Val)
+	}
+
+	return "--" + longopts[optarrind].Name
+}
+
+func lookupKey(longopts []Option, name string) string {
+	if len(name) == 2 && name[0] == '-' && name[1] != '-' {
+		if optarrind := findShortOpt(longopts, int(name[1])); optarrind != -1 {
+			return optionKey(longopts, optarrind)
+		}
+	}
+
+	return name
+}
+
+func record(key string, occurrence Occurrence) {
+	occurrences[key] = append(occurrences[key], occurrence)
+}
+
+/*
+Returns every occurrence of the option name, spelled "--name" for a long option or "-c" for a short
+option, in the order given since parsing started. A short option with a long option of the same Val
+shares its occurrences.
+*/
+func Occurrences(name string) []Occurrence {
+	return occurrences[lookupKey(specLongopts, name)]
+}
+
+/* Reports whether the option name was given, see getoptlong.Occurrences. */
+func Given(name string) bool {
+	return len(Occurrences(name)) > 0
+}
+
+/*
+Returns the argument of the last occurrence of the option name, or its Default if it was not given,
+see getoptlong.Occurrences.
+*/
+func Value(name string) string {
+	key := lookupKey(specLongopts, name)
+
+	if occurrence := occurrences[key]; len(occurrence) > 0 {
+		return occurrence[len(occurrence)-1].Arg
+	}
+
+	for optarrind := range specLongopts {
+		if optionKey(specLongopts, optarrind) == key {
+			return specLongopts[optarrind].Default
+		}
+	}
+
+	return ""
+}
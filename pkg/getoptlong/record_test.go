@@ -0,0 +1,97 @@
+/*
+	@file      pkg/getoptlong/record_test.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong_test
+
+import (
+	"testing"
+
+	"github.com/BChristieDev/getopt_long.go/pkg/getoptlong"
+)
+
+func TestRecord(t *testing.T) {
+	t.Run("Occurrences", func(t *testing.T) {
+		args := []string{"", "-vo", "foo", "--verbose", "--output=bar", "-v", "baz"}
+		longopts := []getoptlong.Option{
+			{Name: "verbose", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'v'},
+			{Name: "output", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'o'},
+		}
+
+		t.Cleanup(func() { cleanup(t) })
+
+		for getoptlong.Parse(len(args), args, "vo:", longopts, nil) != -1 {
+		}
+
+		verbose := getoptlong.Occurrences("--verbose")
+
+		if len(verbose) != 3 {
+			t.Fatalf("occurrences of '--verbose' is '%d'. Expected '3'.\n", len(verbose))
+		}
+
+		for i, index := range []int{1, 3, 5} {
+			if verbose[i].Index != index {
+				t.Errorf("index of occurrence '%d' is '%d'. Expected '%d'.\n", i, verbose[i].Index, index)
+			}
+		}
+
+		if verbose[1].Spelling != "--verbose" {
+			t.Errorf("spelling is '%s'. Expected '--verbose'.\n", verbose[1].Spelling)
+		}
+
+		output := getoptlong.Occurrences("-o")
+
+		if len(output) != 2 || output[0].Spelling != "-o" || output[0].Arg != "foo" || output[1].Index != 4 {
+			t.Errorf("occurrences of '-o' is '%v'. Expected '[{1 -o foo} {4 --output bar}]'.\n", output)
+		}
+
+		if getoptlong.Value("--output") != "bar" {
+			t.Errorf("value is '%s'. Expected 'bar'.\n", getoptlong.Value("--output"))
+		}
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		args := []string{"", "foo"}
+		longopts := []getoptlong.Option{
+			{Name: "output", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'o', Default: "a.out"},
+			{Name: "", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'q'},
+		}
+
+		t.Cleanup(func() { cleanup(t) })
+
+		for getoptlong.Parse(len(args), args, "o:q", longopts, nil) != -1 {
+		}
+
+		if getoptlong.Given("--output") {
+			t.Errorf("option '--output' is given. Expected not given.\n")
+		}
+
+		if getoptlong.Value("-o") != "a.out" {
+			t.Errorf("value is '%s'. Expected 'a.out'.\n", getoptlong.Value("-o"))
+		}
+
+		if getoptlong.Given("-q") {
+			t.Errorf("option '-q' is given. Expected not given.\n")
+		}
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		args := []string{"", "-q"}
+
+		t.Cleanup(func() { cleanup(t) })
+
+		for getoptlong.Parse(len(args), args, "q", nil, nil) != -1 {
+		}
+
+		getoptlong.OptInd = 1
+		args = []string{"", "foo"}
+
+		for getoptlong.Parse(len(args), args, "q", nil, nil) != -1 {
+		}
+
+		if getoptlong.Given("-q") {
+			t.Errorf("option '-q' is given. Expected not given.\n")
+		}
+	})
+}
@@ -0,0 +1,108 @@
+/*
+	@file      pkg/getoptlong/response.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+type responseFiles struct {
+	argv     []string
+	origins  []string
+	dashdash bool
+	/* Absolute paths of the response files being read, to detect a file including itself. */
+	stack []string
+}
+
+func (r *responseFiles) add(arg string, origin string) error {
+	switch {
+	case r.dashdash || !strings.HasPrefix(arg, "@") || arg == "@":
+		r.dashdash = r.dashdash || arg == "--"
+	case strings.HasPrefix(arg, "@@"):
+		arg = arg[1:]
+	default:
+		return r.include(arg[1:], origin)
+	}
+
+	r.argv = append(r.argv, arg)
+	r.origins = append(r.origins, origin)
+
+	return nil
+}
+
+func (r *responseFiles) include(path string, origin string) error {
+	prefix := ""
+
+	if origin != "" {
+		prefix = origin + ": "
+	}
+
+	abs, err := filepath.Abs(path)
+
+	if err != nil {
+		return fmt.Errorf("%s%v", prefix, err)
+	}
+
+	if slices.Contains(r.stack, abs) {
+		return fmt.Errorf("%sresponse file '%s' includes itself", prefix, path)
+	}
+
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return fmt.Errorf("%s%v", prefix, err)
+	}
+
+	words, err := splitOffsets(string(data), nil)
+	var syntaxErr *SyntaxError
+
+	if errors.As(err, &syntaxErr) {
+		return fmt.Errorf("%s:%d: %s", path, syntaxErr.Line, syntaxErr.Msg)
+	}
+
+	r.stack = append(r.stack, abs)
+
+	for _, word := range words {
+		if err := r.add(word.text, fmt.Sprintf("%s:%d", path, lineOf(string(data), word.offset))); err != nil {
+			return err
+		}
+	}
+
+	r.stack = r.stack[:len(r.stack)-1]
+
+	return nil
+}
+
+/*
+Replaces each element "@file" of argv after argv[0] with the words of the response file, split like
+a shell splits them, and assigns the origins of the result to OptOrigin, "file:line" for the words
+of a response file. Response files may name other response files, an element "@@text" is replaced by
+"@text", and no element after "--" is expanded. An error is returned, prefixed by the origin of the
+element naming it, if a response file cannot be read or split, or names itself.
+*/
+func ExpandResponseFiles(argv []string) ([]string, error) {
+	r := responseFiles{}
+
+	for i, arg := range argv {
+		if i == 0 {
+			r.argv = append(r.argv, arg)
+			r.origins = append(r.origins, origin(argv, i))
+
+			continue
+		}
+
+		if err := r.add(arg, origin(argv, i)); err != nil {
+			return nil, err
+		}
+	}
+
+	return withOrigins(r.argv, r.origins), nil
+}
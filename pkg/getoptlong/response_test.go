@@ -0,0 +1,126 @@
+/*
+	@file      pkg/getoptlong/response_test.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/BChristieDev/getopt_long.go/pkg/getoptlong"
+)
+
+func TestExpandResponseFiles(t *testing.T) {
+	dir := t.TempDir()
+	args := filepath.Join(dir, "args.txt")
+	nested := filepath.Join(dir, "nested.txt")
+	loop := filepath.Join(dir, "loop.txt")
+	bad := filepath.Join(dir, "bad.txt")
+
+	os.WriteFile(args, []byte("-o 'out file'\n--verbose \\\n  @"+nested+"\n@@literal\n"), 0o644)
+	os.WriteFile(nested, []byte("# not a comment\n--frob\n"), 0o644)
+	os.WriteFile(loop, []byte("-a\n@"+loop+"\n"), 0o644)
+	os.WriteFile(bad, []byte("-a\n-b \"unterminated\n"), 0o644)
+
+	t.Run("Expansion", func(t *testing.T) {
+		argv := []string{"prog", "-x", "@" + args, "--", "@" + args, "@@x"}
+		expected := []string{"prog", "-x", "-o", "out file", "--verbose", "#", "not", "a", "comment", "--frob", "@literal", "--", "@" + args, "@@x"}
+		origins := []string{
+			"", "",
+			args + ":1", args + ":1", args + ":2",
+			nested + ":1", nested + ":1", nested + ":1", nested + ":1", nested + ":2",
+			args + ":4",
+			"", "", "",
+		}
+
+		t.Cleanup(func() { cleanup(t) })
+
+		if actual, err := getoptlong.ExpandResponseFiles(argv); err != nil || !slices.Equal(actual, expected) {
+			t.Errorf("argv is '%q' (%v). Expected '%q'.\n", actual, err, expected)
+		}
+
+		if !slices.Equal(getoptlong.OptOrigin, origins) {
+			t.Errorf("OptOrigin is '%q'. Expected '%q'.\n", getoptlong.OptOrigin, origins)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			arg      string
+			expected string
+		}{
+			{"Cycle", "@" + loop, loop + ":2: response file '" + loop + "' includes itself"},
+			{"Syntax", "@" + bad, bad + ":2: unterminated double quote"},
+			{"Missing file", "@" + filepath.Join(dir, "missing.txt"), "open " + filepath.Join(dir, "missing.txt") + ": no such file or directory"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				t.Cleanup(func() { cleanup(t) })
+
+				if _, err := getoptlong.ExpandResponseFiles([]string{"prog", test.arg}); err == nil || err.Error() != test.expected {
+					t.Errorf("error is '%v'. Expected '%s'.\n", err, test.expected)
+				}
+			})
+		}
+	})
+
+	t.Run("Diagnostics name the file and line", func(t *testing.T) {
+		opts := filepath.Join(dir, "opts.txt")
+		longopts := []getoptlong.Option{
+			{Name: "verbose", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'v'},
+		}
+		expected := "prog: unrecognized option '--frob' (from " + opts + ":2)"
+
+		os.WriteFile(opts, []byte("--verbose\n--frob\n"), 0o644)
+		t.Cleanup(func() { cleanup(t) })
+
+		getoptlong.OptErr = 0
+		argv, _ := getoptlong.ExpandResponseFiles([]string{"prog", "@" + opts})
+
+		if opt := getoptlong.Parse(len(argv), argv, "", longopts, nil); opt != 'v' {
+			t.Errorf("opt is '%c'. Expected 'v'.\n", opt)
+		}
+
+		if opt := getoptlong.Parse(len(argv), argv, "", longopts, nil); opt != '?' {
+			t.Errorf("opt is '%c'. Expected '?'.\n", opt)
+		}
+
+		if getoptlong.LastError == nil || getoptlong.LastError.Error() != expected {
+			t.Errorf("LastError is '%v'. Expected '%s'.\n", getoptlong.LastError, expected)
+		}
+	})
+
+	t.Run("Origins are of the expanded argv only", func(t *testing.T) {
+		opts := filepath.Join(dir, "same.txt")
+		longopts := []getoptlong.Option{
+			{Name: "verbose", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'v'},
+		}
+		root := &getoptlong.Command{Name: "prog", Longopts: longopts, Run: func(args []string) int { return 0 }}
+		expected := "prog: unrecognized option '--frob'"
+
+		os.WriteFile(opts, []byte("--verbose\n--verbose\n"), 0o644)
+		t.Cleanup(func() { cleanup(t) })
+
+		getoptlong.OptErr = 0
+		argv, _ := getoptlong.ExpandResponseFiles([]string{"prog", "@" + opts})
+
+		if status := getoptlong.Execute(root, argv); status != 0 {
+			t.Errorf("status is '%d'. Expected '0'.\n", status)
+		}
+
+		args := []string{"prog", "-v", "--frob"}
+		getoptlong.OptInd = 0
+
+		for opt := getoptlong.Parse(len(args), args, "v", longopts, nil); opt != -1; opt = getoptlong.Parse(len(args), args, "v", longopts, nil) {
+			if opt == '?' && (getoptlong.LastError == nil || getoptlong.LastError.Error() != expected) {
+				t.Errorf("LastError is '%v'. Expected '%s'.\n", getoptlong.LastError, expected)
+			}
+		}
+	})
+}
@@ -0,0 +1,224 @@
+/*
+	@file      pkg/getoptlong/schema.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type schemaShort struct {
+	Char   string `json:"char"`
+	HasArg string `json:"hasArg"`
+}
+
+type schemaOption struct {
+	Name         string   `json:"name,omitempty"`
+	HasArg       string   `json:"hasArg"`
+	Flag         bool     `json:"flag,omitempty"`
+	Val          int      `json:"val"`
+	Choices      []string `json:"choices,omitempty"`
+	ChoicePrefix bool     `json:"choicePrefix,omitempty"`
+	Required     bool     `json:"required,omitempty"`
+	Default      string   `json:"default,omitempty"`
+	Description  string   `json:"description,omitempty"`
+	ArgName      string   `json:"argName,omitempty"`
+	Section      string   `json:"section,omitempty"`
+	Hidden       bool     `json:"hidden,omitempty"`
+	Env          string   `json:"env,omitempty"`
+}
+
+type schemaGroup struct {
+	Kind    string   `json:"kind"`
+	Options []string `json:"options"`
+}
+
+type schemaCommand struct {
+	Name           string          `json:"name"`
+	Summary        string          `json:"summary,omitempty"`
+	Description    string          `json:"description,omitempty"`
+	Silent         bool            `json:"silent,omitempty"`
+	Short          []schemaShort   `json:"short,omitempty"`
+	Options        []schemaOption  `json:"options,omitempty"`
+	Groups         []schemaGroup   `json:"groups,omitempty"`
+	Operands       []string        `json:"operands,omitempty"`
+	Commands       []schemaCommand `json:"commands,omitempty"`
+	CommandPrefix  bool            `json:"commandPrefix,omitempty"`
+	CommandSuggest int             `json:"commandSuggest,omitempty"`
+}
+
+var (
+	hasArgNames = []string{"none", "required", "optional", "boolean"}
+	groupKinds  = []string{"mutually-exclusive", "all-or-none", "at-least-one"}
+)
+
+func schemaName(names []string, value int) string {
+	if value < 0 || value >= len(names) {
+		return fmt.Sprint(value)
+	}
+
+	return names[value]
+}
+
+func schemaValue(names []string, name string) (int, error) {
+	for value := range names {
+		if names[value] == name {
+			return value, nil
+		}
+	}
+
+	return 0, fmt.Errorf("getoptlong: unknown value '%s', expected one of '%s'", name, strings.Join(names, "', '"))
+}
+
+func exportCommand(cmd *Command) schemaCommand {
+	schema := schemaCommand{
+		Name:           cmd.Name,
+		Summary:        cmd.Summary,
+		Description:    cmd.Description,
+		Silent:         strings.HasPrefix(cmd.Shortopts, ":"),
+		Operands:       cmd.Operands,
+		CommandPrefix:  cmd.CommandPrefix,
+		CommandSuggest: cmd.CommandSuggest,
+	}
+
+	for _, e := range entries(cmd.Shortopts, nil) {
+		schema.Short = append(schema.Short, schemaShort{Char: string(rune(e.short)), HasArg: schemaName(hasArgNames, e.shortArg)})
+	}
+
+	for _, option := range cmd.Longopts {
+		schema.Options = append(schema.Options, schemaOption{
+			Name:         option.Name,
+			HasArg:       schemaName(hasArgNames, option.HasArg),
+			Flag:         option.Flag != nil,
+			Val:          option.Val,
+			Choices:      option.Choices,
+			ChoicePrefix: option.ChoicePrefix,
+			Required:     option.Required,
+			Default:      option.Default,
+			Description:  option.Description,
+			ArgName:      option.ArgName,
+			Section:      option.Section,
+			Hidden:       option.Hidden,
+			Env:          option.Env,
+		})
+	}
+
+	for _, group := range cmd.Groups {
+		schema.Groups = append(schema.Groups, schemaGroup{Kind: schemaName(groupKinds, group.Kind), Options: group.Options})
+	}
+
+	for _, sub := range cmd.Commands {
+		schema.Commands = append(schema.Commands, exportCommand(sub))
+	}
+
+	return schema
+}
+
+func importCommand(schema schemaCommand) (*Command, error) {
+	var shortopts strings.Builder
+	cmd := &Command{
+		Name:           schema.Name,
+		Summary:        schema.Summary,
+		Description:    schema.Description,
+		Operands:       schema.Operands,
+		CommandPrefix:  schema.CommandPrefix,
+		CommandSuggest: schema.CommandSuggest,
+	}
+
+	if schema.Silent {
+		shortopts.WriteString(":")
+	}
+
+	for _, short := range schema.Short {
+		hasArg, err := schemaValue(hasArgNames[:OptionalArgument+1], short.HasArg)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(short.Char) != 1 || short.Char == ":" {
+			return nil, fmt.Errorf("getoptlong: invalid short option '%s'", short.Char)
+		}
+
+		shortopts.WriteString(short.Char + strings.Repeat(":", hasArg))
+	}
+
+	cmd.Shortopts = shortopts.String()
+
+	for _, schemaOption := range schema.Options {
+		hasArg, err := schemaValue(hasArgNames, schemaOption.HasArg)
+
+		if err != nil {
+			return nil, err
+		}
+
+		option := Option{
+			Name:         schemaOption.Name,
+			HasArg:       hasArg,
+			Val:          schemaOption.Val,
+			Choices:      schemaOption.Choices,
+			ChoicePrefix: schemaOption.ChoicePrefix,
+			Required:     schemaOption.Required,
+			Default:      schemaOption.Default,
+			Description:  schemaOption.Description,
+			ArgName:      schemaOption.ArgName,
+			Section:      schemaOption.Section,
+			Hidden:       schemaOption.Hidden,
+			Env:          schemaOption.Env,
+		}
+
+		if schemaOption.Flag {
+			option.Flag = new(int)
+		}
+
+		cmd.Longopts = append(cmd.Longopts, option)
+	}
+
+	for _, group := range schema.Groups {
+		kind, err := schemaValue(groupKinds, group.Kind)
+
+		if err != nil {
+			return nil, err
+		}
+
+		cmd.Groups = append(cmd.Groups, Group{Kind: kind, Options: group.Options})
+	}
+
+	for _, schemaSub := range schema.Commands {
+		sub, err := importCommand(schemaSub)
+
+		if err != nil {
+			return nil, err
+		}
+
+		cmd.Commands = append(cmd.Commands, sub)
+	}
+
+	return cmd, nil
+}
+
+/*
+Encodes the command, its options and subcommands as indented JSON, with shortopts decoded into one
+entry per short option. Flag pointers and functions are not encoded, only whether Flag is nil.
+*/
+func MarshalSchema(cmd *Command) ([]byte, error) {
+	return json.MarshalIndent(exportCommand(cmd), "", "  ")
+}
+
+/*
+Decodes a command encoded by getoptlong.MarshalSchema. Options encoded with a Flag are given a Flag
+pointing to a new integer.
+*/
+func UnmarshalSchema(data []byte) (*Command, error) {
+	var schema schemaCommand
+
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, err
+	}
+
+	return importCommand(schema)
+}
@@ -0,0 +1,83 @@
+/*
+	@file      pkg/getoptlong/schema_test.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong_test
+
+import (
+	"testing"
+
+	"github.com/BChristieDev/getopt_long.go/pkg/getoptlong"
+)
+
+func TestSchema(t *testing.T) {
+	t.Run("Export", func(t *testing.T) {
+		data, err := getoptlong.MarshalSchema(docsCommand)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		golden(t, "schema.json.golden", string(data)+"\n")
+	})
+
+	t.Run("Round trip", func(t *testing.T) {
+		data, _ := getoptlong.MarshalSchema(docsCommand)
+		cmd, err := getoptlong.UnmarshalSchema(data)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if cmd.Shortopts != docsCommand.Shortopts {
+			t.Errorf("shortopts is '%s'. Expected '%s'.\n", cmd.Shortopts, docsCommand.Shortopts)
+		}
+
+		again, _ := getoptlong.MarshalSchema(cmd)
+
+		if string(again) != string(data) {
+			t.Errorf("schema is '%s'. Expected '%s'.\n", again, data)
+		}
+	})
+
+	t.Run("Imported spec parses", func(t *testing.T) {
+		data := `{
+			"name": "prog",
+			"silent": true,
+			"short": [{"char": "a", "hasArg": "none"}, {"char": "b", "hasArg": "optional"}, {"char": "c", "hasArg": "required"}],
+			"options": [{"name": "frob", "hasArg": "boolean", "flag": true, "val": 0}]
+		}`
+		args := []string{"", "-ab", "-c", "foo", "--no-frob", "bar"}
+		cmd, err := getoptlong.UnmarshalSchema([]byte(data))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Cleanup(func() { cleanup(t) })
+
+		if cmd.Shortopts != ":ab::c:" {
+			t.Errorf("shortopts is '%s'. Expected ':ab::c:'.\n", cmd.Shortopts)
+		}
+
+		*cmd.Longopts[0].Flag = getoptlong.BoolUnset
+
+		for getoptlong.Parse(len(args), args, cmd.Shortopts, cmd.Longopts, nil) != -1 {
+		}
+
+		if *cmd.Longopts[0].Flag != getoptlong.BoolFalse {
+			t.Errorf("flag 'frob' is '%d'. Expected '%d'.\n", *cmd.Longopts[0].Flag, getoptlong.BoolFalse)
+		}
+
+		if args[getoptlong.OptInd] != "bar" {
+			t.Errorf("positional argument is '%s'. Expected 'bar'.\n", args[getoptlong.OptInd])
+		}
+	})
+
+	t.Run("Invalid argument kind", func(t *testing.T) {
+		if _, err := getoptlong.UnmarshalSchema([]byte(`{"name": "prog", "options": [{"name": "foo", "hasArg": "maybe"}]}`)); err == nil {
+			t.Errorf("error is 'nil'. Expected an error.\n")
+		}
+	})
+}
@@ -0,0 +1,187 @@
+/*
+	@file      pkg/getoptlong/shebang.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const blanks = " \t\n\v\f\r"
+
+/* Returns the argument of the interpreter of the "#!" line the file at path starts with, if any. */
+func shebangArg(path string) (string, bool) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return "", false
+	}
+
+	defer file.Close()
+
+	line, err := bufio.NewReader(file).ReadString('\n')
+
+	if !strings.HasPrefix(line, "#!") || (err != nil && line == "") {
+		return "", false
+	}
+
+	line = strings.TrimLeft(strings.TrimSuffix(line[2:], "\n"), " \t")
+	end := strings.IndexAny(line, " \t")
+
+	if end == -1 {
+		return "", false
+	}
+
+	return strings.Trim(line[end:], " \t"), true
+}
+
+/*
+Splits s into words like env -S: words are separated by blanks, single quotes quote everything up to
+the next single quote except "\\" and "\'", and double quotes quote everything up to the next double
+quote. Outside of single quotes a backslash escapes a backslash, a quote, '#' and '$', \f, \n, \r,
+\t and \v are replaced by their control character, \_ is replaced by a space within double quotes
+and separates words outside of them, and \c ends the string. A '#' starting a word starts a
+comment, and ${NAME} is replaced by the value of the environment variable NAME.
+*/
+func splitEnvS(s string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote byte
+	start := 0
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case quote == 0 && strings.IndexByte(blanks, c) != -1:
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		case quote == 0 && c == '#' && !inWord:
+			return words, nil
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '\'' || c == '"'):
+			quote = c
+			start = i
+			inWord = true
+		case c == '\\' && quote == '\'':
+			if i+1 < len(s) && (s[i+1] == '\\' || s[i+1] == '\'') {
+				i++
+			}
+
+			word.WriteByte(s[i])
+		case c == '\\':
+			if i+1 == len(s) {
+				return nil, syntaxError(s, "trailing backslash", i)
+			}
+
+			i++
+
+			switch e := s[i]; {
+			case e == 'c':
+				if quote != 0 {
+					return nil, syntaxError(s, "'\\c' in double quotes", i-1)
+				}
+
+				if inWord {
+					words = append(words, word.String())
+				}
+
+				return words, nil
+			case e == '_' && quote == 0:
+				if inWord {
+					words = append(words, word.String())
+					word.Reset()
+					inWord = false
+				}
+
+				continue
+			case e == '_':
+				word.WriteByte(' ')
+			case strings.IndexByte("fnrtv", e) != -1:
+				word.WriteByte("\f\n\r\t\v"[strings.IndexByte("fnrtv", e)])
+			case strings.IndexByte("\\\"'#$", e) != -1:
+				word.WriteByte(e)
+			default:
+				return nil, syntaxError(s, fmt.Sprintf("invalid backslash '\\%c'", e), i-1)
+			}
+
+			inWord = true
+		case c == '$' && quote != '\'':
+			end := strings.IndexByte(s[i:], '}')
+
+			if !strings.HasPrefix(s[i:], "${") || end == -1 || !isName(s[i+2:i+end]) {
+				return nil, syntaxError(s, "only ${NAME} expansion is supported", i)
+			}
+
+			word.WriteString(os.Getenv(s[i+2 : i+end]))
+			inWord = true
+			i += end
+		default:
+			word.WriteByte(c)
+			inWord = true
+		}
+	}
+
+	if quote == '\'' {
+		return nil, syntaxError(s, "unterminated single quote", start)
+	}
+
+	if quote == '"' {
+		return nil, syntaxError(s, "unterminated double quote", start)
+	}
+
+	if inWord {
+		words = append(words, word.String())
+	}
+
+	return words, nil
+}
+
+/*
+Splits argv[1] into words like env -S splits them if argv is how Linux runs an interpreter for a
+script starting with "#!/path/to/prog -v --config x", that is argv[1] holds every argument of the
+"#!" line, "-v --config x", and argv[2] is the script. Linux passes the rest of the line as a single
+argument, which getoptlong.Parse would otherwise see as one option. The origins of the result are
+assigned to OptOrigin, "script:1" for the words of argv[1]. argv is returned as is if it does not
+look like the arguments of an interpreter, so that the result can be passed on unconditionally to
+programs meant to be used both as interpreters and on their own. An error is returned if the "#!"
+line cannot be split.
+*/
+func ExpandShebang(argv []string) ([]string, error) {
+	if len(argv) < 3 || strings.IndexAny(argv[1], blanks) == -1 {
+		return argv, nil
+	}
+
+	if arg, ok := shebangArg(argv[2]); !ok || arg != argv[1] {
+		return argv, nil
+	}
+
+	words, err := splitEnvS(argv[1])
+
+	if err != nil {
+		return nil, fmt.Errorf("%s:1: %v", argv[2], err)
+	}
+
+	origins := []string{origin(argv, 0)}
+	expanded := append([]string{argv[0]}, words...)
+
+	for range words {
+		origins = append(origins, argv[2]+":1")
+	}
+
+	for i := 2; i < len(argv); i++ {
+		origins = append(origins, origin(argv, i))
+	}
+
+	return withOrigins(append(expanded, argv[2:]...), origins), nil
+}
@@ -0,0 +1,119 @@
+/*
+	@file      pkg/getoptlong/shebang_test.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/BChristieDev/getopt_long.go/pkg/getoptlong"
+)
+
+func writeScript(t *testing.T, shebang string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "script")
+
+	if err := os.WriteFile(path, []byte(shebang+"\nbody\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestExpandShebang(t *testing.T) {
+	t.Run("Arguments", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			shebang  string
+			arg      string
+			expected []string
+		}{
+			{"Blanks", "#!/usr/bin/tool  -v --config x ", "-v --config x", []string{"-v", "--config", "x"}},
+			{"Quotes", `#!/usr/bin/tool -m 'it\'s "here"' "a\tb\_c"`, `-m 'it\'s "here"' "a\tb\_c"`, []string{"-m", `it's "here"`, "a\tb c"}},
+			{"Separators and comments", `#!/usr/bin/tool -v\_-q # comment`, `-v\_-q # comment`, []string{"-v", "-q"}},
+			{"End of string", `#!/usr/bin/tool -v \c -q`, `-v \c -q`, []string{"-v"}},
+			{"Expansions", `#!/usr/bin/tool --home=${SCRIPT_HOME} -v`, `--home=${SCRIPT_HOME} -v`, []string{"--home=/srv", "-v"}},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				script := writeScript(t, test.shebang)
+				argv := []string{"/usr/bin/tool", test.arg, script, "operand"}
+				expected := append(append([]string{"/usr/bin/tool"}, test.expected...), script, "operand")
+
+				t.Setenv("SCRIPT_HOME", "/srv")
+				t.Cleanup(func() { cleanup(t) })
+
+				if actual, err := getoptlong.ExpandShebang(argv); err != nil || !slices.Equal(actual, expected) {
+					t.Errorf("argv is '%q' (%v). Expected '%q'.\n", actual, err, expected)
+				}
+			})
+		}
+	})
+
+	t.Run("Errors name the script", func(t *testing.T) {
+		longopts := []getoptlong.Option{
+			{Name: "config", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'c'},
+		}
+		script := writeScript(t, "#!/usr/bin/tool --confg x")
+		expected := "tool: unrecognized option '--confg' (from " + script + ":1)\nDid you mean '--config'?"
+
+		t.Cleanup(func() { cleanup(t) })
+
+		getoptlong.OptErr = 0
+		argv, err := getoptlong.ExpandShebang([]string{"tool", "--confg x", script})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if opt := getoptlong.Parse(len(argv), argv, "c:", longopts, nil); opt != '?' {
+			t.Errorf("opt is '%c'. Expected '?'.\n", opt)
+		}
+
+		if getoptlong.LastError == nil || getoptlong.LastError.Error() != expected {
+			t.Errorf("LastError is '%v'. Expected '%s'.\n", getoptlong.LastError, expected)
+		}
+
+		for getoptlong.Parse(len(argv), argv, "c:", longopts, nil) != -1 {
+		}
+	})
+
+	t.Run("Not an interpreter", func(t *testing.T) {
+		script := writeScript(t, "#!/usr/bin/tool -v")
+		tests := []struct {
+			name string
+			argv []string
+		}{
+			{"Single argument", []string{"tool", "-v", script}},
+			{"Operand with blanks", []string{"tool", "a b", script}},
+			{"Not a script", []string{"tool", "a b", filepath.Join(t.TempDir(), "missing")}},
+			{"Too few arguments", []string{"tool", "a b"}},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				t.Cleanup(func() { cleanup(t) })
+
+				if actual, err := getoptlong.ExpandShebang(test.argv); err != nil || !slices.Equal(actual, test.argv) {
+					t.Errorf("argv is '%q' (%v). Expected '%q'.\n", actual, err, test.argv)
+				}
+			})
+		}
+	})
+
+	t.Run("Syntax error", func(t *testing.T) {
+		script := writeScript(t, `#!/usr/bin/tool -v "x`)
+		expected := script + ":1: unterminated double quote at offset 3"
+
+		if _, err := getoptlong.ExpandShebang([]string{"tool", `-v "x`, script}); err == nil || err.Error() != expected {
+			t.Errorf("error is '%v'. Expected '%s'.\n", err, expected)
+		}
+	})
+}
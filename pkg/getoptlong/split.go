@@ -0,0 +1,254 @@
+/*
+	@file      pkg/getoptlong/split.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+import (
+	"fmt"
+	"strings"
+)
+
+/* A word split from a string, and the offset in the string the word starts at. */
+type splitWord struct {
+	text   string
+	offset int
+}
+
+/* An error splitting a string with getoptlong.Split, and where in the string it is. */
+type SyntaxError struct {
+	/* Description of the error, e.g. "unterminated double quote". */
+	Msg string
+	/* Byte offset of the error in the string, counting from 0. */
+	Offset int
+	/* Line and column of the error in the string, counting from 1. */
+	Line   int
+	Column int
+}
+
+/* Returns Msg followed by its Offset. */
+func (err *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at offset %d", err.Msg, err.Offset)
+}
+
+func syntaxError(s string, msg string, offset int) *SyntaxError {
+	return &SyntaxError{
+		Msg:    msg,
+		Offset: offset,
+		Line:   lineOf(s, offset),
+		Column: offset - strings.LastIndexByte(s[:offset], '\n'),
+	}
+}
+
+/* Returns the line of s offset is in, counting from 1. */
+func lineOf(s string, offset int) int {
+	return strings.Count(s[:offset], "\n") + 1
+}
+
+func isNameChar(c byte, first bool) bool {
+	return c == '_' || (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (!first && c >= '0' && c <= '9')
+}
+
+func isName(name string) bool {
+	for i := range len(name) {
+		if !isNameChar(name[i], i == 0) {
+			return false
+		}
+	}
+
+	return name != ""
+}
+
+/*
+Returns the value of the parameter expansion s[i:] starts with, $NAME or ${NAME}, and its length, or
+a length of 0 if it does not start with one.
+*/
+func expand(s string, i int, getenv func(string) string) (string, int, error) {
+	if i+1 < len(s) && s[i+1] == '{' {
+		end := strings.IndexByte(s[i+2:], '}')
+
+		if end == -1 {
+			return "", 0, syntaxError(s, "unterminated parameter expansion", i)
+		}
+
+		name := s[i+2 : i+2+end]
+
+		if !isName(name) {
+			return "", 0, syntaxError(s, "bad substitution", i)
+		}
+
+		return getenv(name), end + 3, nil
+	}
+
+	n := 1
+
+	for i+n < len(s) && isNameChar(s[i+n], n == 1) {
+		n++
+	}
+
+	if n == 1 {
+		return "", 0, nil
+	}
+
+	return getenv(s[i+1 : i+n]), n, nil
+}
+
+/*
+Splits s into words like a POSIX shell: words are separated by blanks, a backslash quotes the next
+character, single quotes quote everything up to the next single quote, and double quotes quote
+everything up to the next double quote except a backslash before '"', '\', '$' or '`'. A
+backslash-newline is removed outside of single quotes.
+
+If getenv is not nil, $NAME and ${NAME} outside of single quotes are replaced by getenv(NAME), e.g.
+os.Getenv. Unlike a shell the value is not split into words, nor are other expansions performed,
+and a word made only of unquoted expansions with empty values is removed.
+
+A quote left open or a trailing backslash is reported as a *SyntaxError.
+*/
+func Split(s string, getenv func(string) string) ([]string, error) {
+	var words []string
+	splitWords, err := splitOffsets(s, getenv)
+
+	for _, word := range splitWords {
+		words = append(words, word.text)
+	}
+
+	return words, err
+}
+
+/* Splits s into words along with their offsets, see getoptlong.Split. */
+func splitOffsets(s string, getenv func(string) string) ([]splitWord, error) {
+	var words []splitWord
+	var word strings.Builder
+	inWord := false
+	start := 0
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		if !inWord {
+			start = i
+		}
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			if inWord {
+				words = append(words, splitWord{word.String(), start})
+				word.Reset()
+				inWord = false
+			}
+		case c == '\\':
+			if i+1 == len(s) {
+				return nil, syntaxError(s, "trailing backslash", i)
+			}
+
+			i++
+
+			if s[i] != '\n' {
+				word.WriteByte(s[i])
+				inWord = true
+			}
+		case c == '\'':
+			end := strings.IndexByte(s[i+1:], '\'')
+
+			if end == -1 {
+				return nil, syntaxError(s, "unterminated single quote", i)
+			}
+
+			word.WriteString(s[i+1 : i+1+end])
+			inWord = true
+			i += end + 1
+		case c == '"':
+			quote := i
+
+			for i++; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '$' && getenv != nil {
+					value, n, err := expand(s, i, getenv)
+
+					if err != nil {
+						return nil, err
+					}
+
+					if n > 0 {
+						word.WriteString(value)
+						i += n - 1
+
+						continue
+					}
+				}
+
+				if s[i] == '\\' && i+1 < len(s) && strings.IndexByte("\"\\$`\n", s[i+1]) != -1 {
+					i++
+
+					if s[i] == '\n' {
+						continue
+					}
+				}
+
+				word.WriteByte(s[i])
+			}
+
+			if i == len(s) {
+				return nil, syntaxError(s, "unterminated double quote", quote)
+			}
+
+			inWord = true
+		case c == '$' && getenv != nil:
+			value, n, err := expand(s, i, getenv)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if n == 0 {
+				word.WriteByte(c)
+				inWord = true
+
+				continue
+			}
+
+			word.WriteString(value)
+			inWord = inWord || value != ""
+			i += n - 1
+		default:
+			word.WriteByte(c)
+			inWord = true
+		}
+	}
+
+	if inWord {
+		words = append(words, splitWord{word.String(), start})
+	}
+
+	return words, nil
+}
+
+/*
+Parses the options of the command line cmdline like getoptlong.Parse parses the options of argv,
+e.g. "deploy -v --env prod" received by a chat bot, its first word being the program name. On the
+first call, when argv points to a nil slice, cmdline is split with getoptlong.Split without
+expansions and the words are assigned to the slice argv points to, so that the operands are
+(*argv)[OptInd:] once -1 is returned.
+
+If cmdline cannot be split '?' is returned, LastError describes the error, and argv is assigned an
+empty slice so that the next call returns -1.
+*/
+func ParseString(cmdline string, argv *[]string, shortopts string, longopts []Option, indexptr *int) int {
+	if *argv == nil {
+		words, err := Split(cmdline, nil)
+		*argv = withOrigins(append([]string{}, words...), nil)
+
+		if err != nil {
+			errAt(nil, -1)
+
+			return errInvalidOpt(err.Error(), 0)
+		}
+	}
+
+	if len(*argv) == 0 {
+		return -1
+	}
+
+	return Parse(len(*argv), *argv, shortopts, longopts, indexptr)
+}
@@ -0,0 +1,133 @@
+/*
+	@file      pkg/getoptlong/split_test.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/BChristieDev/getopt_long.go/pkg/getoptlong"
+)
+
+func TestSplit(t *testing.T) {
+	env := map[string]string{"HOME": "/home/me", "EMPTY": "", "MSG": "hello world"}
+	getenv := func(name string) string { return env[name] }
+
+	t.Run("Words", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			s        string
+			expected []string
+		}{
+			{"Blanks", " a\tb\n c ", []string{"a", "b", "c"}},
+			{"Single quotes", `'a b' 'it''s' '\n'`, []string{"a b", "its", `\n`}},
+			{"Double quotes", `"a \"b\" \\ \$x \q" ""`, []string{`a "b" \ $x \q`, ""}},
+			{"Backslashes", `a\ b \'c\"`, []string{"a b", `'c"`}},
+			{"Backslash-newline", "ab\\\ncd \"ef\\\ngh\" 'ij\\\nkl'", []string{"abcd", "efgh", "ij\\\nkl"}},
+			{"Expansions", `$HOME/x "${HOME}y" '$HOME' \$HOME`, []string{"/home/me/x", "/home/mey", "$HOME", "$HOME"}},
+			{"Values are not split", `$MSG "$MSG"`, []string{"hello world", "hello world"}},
+			{"Empty values", `$EMPTY "$EMPTY" a$EMPTY`, []string{"", "a"}},
+			{"Not a parameter", `$ $1 a$-b "$"`, []string{"$", "$1", "a$-b", "$"}},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				if actual, err := getoptlong.Split(test.s, getenv); err != nil || !slices.Equal(actual, test.expected) {
+					t.Errorf("words are '%q' (%v). Expected '%q'.\n", actual, err, test.expected)
+				}
+			})
+		}
+	})
+
+	t.Run("Without expansions", func(t *testing.T) {
+		expected := []string{"$HOME", "${HOME}"}
+
+		if actual, err := getoptlong.Split(`$HOME "${HOME}"`, nil); err != nil || !slices.Equal(actual, expected) {
+			t.Errorf("words are '%q' (%v). Expected '%q'.\n", actual, err, expected)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			s        string
+			expected getoptlong.SyntaxError
+		}{
+			{"Single quote", "a 'b", getoptlong.SyntaxError{Msg: "unterminated single quote", Offset: 2, Line: 1, Column: 3}},
+			{"Double quote", "a\n  \"b\nc", getoptlong.SyntaxError{Msg: "unterminated double quote", Offset: 4, Line: 2, Column: 3}},
+			{"Trailing backslash", `a\`, getoptlong.SyntaxError{Msg: "trailing backslash", Offset: 1, Line: 1, Column: 2}},
+			{"Unterminated expansion", "${HOME", getoptlong.SyntaxError{Msg: "unterminated parameter expansion", Offset: 0, Line: 1, Column: 1}},
+			{"Bad substitution", `"${1x}"`, getoptlong.SyntaxError{Msg: "bad substitution", Offset: 1, Line: 1, Column: 2}},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				var syntaxErr *getoptlong.SyntaxError
+
+				if _, err := getoptlong.Split(test.s, getenv); !errors.As(err, &syntaxErr) || *syntaxErr != test.expected {
+					t.Errorf("error is '%+v'. Expected '%+v'.\n", err, test.expected)
+				}
+			})
+		}
+	})
+}
+
+func TestParseString(t *testing.T) {
+	longopts := []getoptlong.Option{
+		{Name: "env", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'e'},
+		{Name: "verbose", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'v'},
+	}
+
+	t.Run("Options and operands", func(t *testing.T) {
+		expected := []string{"v ", "e staging eu"}
+		operands := []string{"web", "$HOME"}
+		var actual []string
+		var argv []string
+
+		t.Cleanup(func() { cleanup(t) })
+
+		for {
+			opt := getoptlong.ParseString(`deploy -v --env 'staging eu' web $HOME`, &argv, "e:v", longopts, nil)
+
+			if opt == -1 {
+				break
+			}
+
+			actual = append(actual, fmt.Sprintf("%c %s", opt, getoptlong.OptArg))
+		}
+
+		if !slices.Equal(actual, expected) {
+			t.Errorf("options are '%q'. Expected '%q'.\n", actual, expected)
+		}
+
+		if !slices.Equal(argv[getoptlong.OptInd:], operands) {
+			t.Errorf("operands are '%q'. Expected '%q'.\n", argv[getoptlong.OptInd:], operands)
+		}
+	})
+
+	t.Run("Syntax error", func(t *testing.T) {
+		var argv []string
+		expected := "unterminated double quote at offset 13"
+
+		t.Cleanup(func() { cleanup(t) })
+
+		getoptlong.OptErr = 0
+
+		if opt := getoptlong.ParseString(`deploy --env "prod`, &argv, "e:v", longopts, nil); opt != '?' {
+			t.Errorf("opt is '%c'. Expected '?'.\n", opt)
+		}
+
+		if getoptlong.LastError == nil || getoptlong.LastError.Error() != expected {
+			t.Errorf("LastError is '%v'. Expected '%s'.\n", getoptlong.LastError, expected)
+		}
+
+		if opt := getoptlong.ParseString(`deploy --env "prod`, &argv, "e:v", longopts, nil); opt != -1 {
+			t.Errorf("opt is '%c'. Expected '-1'.\n", opt)
+		}
+	})
+}
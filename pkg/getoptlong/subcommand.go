@@ -0,0 +1,424 @@
+/*
+	@file      pkg/getoptlong/subcommand.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type commandOptions struct {
+	shortopts string
+	longopts  []Option
+	/* Option of longopts[i] as defined by owners[i]. */
+	options     []*Option
+	owners      []*Command
+	shortOwners map[int]*Command
+	/* Options inherited from the ancestors of the command. */
+	inheritedShortopts string
+	inheritedLongopts  []Option
+}
+
+/*
+Merges the options of the last command of path with the options it inherits from its ancestors. An
+option of a command shadows the name or short option of an option of an ancestor, which stays
+available by the other if it has both.
+*/
+func inherit(path []*Command) *commandOptions {
+	merged := &commandOptions{shortOwners: map[int]*Command{}}
+	names := map[string]bool{}
+
+	for i := len(path) - 1; i >= 0; i-- {
+		cmd := path[i]
+
+		for _, e := range entries(cmd.Shortopts, cmd.Longopts) {
+			shortFree := e.short != 0 && merged.shortOwners[e.short] == nil
+			nameFree := e.long() != "" && !names[e.long()]
+
+			if !shortFree && !nameFree {
+				continue
+			}
+
+			if shortFree {
+				short := string(rune(e.short)) + strings.Repeat(":", e.shortArg)
+				merged.shortopts += short
+				merged.shortOwners[e.short] = cmd
+
+				if i < len(path)-1 {
+					merged.inheritedShortopts += short
+				}
+			}
+
+			if e.option == nil {
+				continue
+			}
+
+			option := *e.option
+
+			if !nameFree {
+				option.Name = ""
+			}
+
+			/* Unlinks the long option from the short option of the command shadowing it. */
+			if e.short != 0 && !shortFree {
+				option.Flag = new(int)
+			}
+
+			names[option.Name] = option.Name != ""
+			merged.longopts = append(merged.longopts, option)
+			merged.options = append(merged.options, e.option)
+			merged.owners = append(merged.owners, cmd)
+
+			if i < len(path)-1 {
+				merged.inheritedLongopts = append(merged.inheritedLongopts, option)
+			}
+		}
+	}
+
+	return merged
+}
+
+/*
+Dispatches the options of the last command of path and of its ancestors that were not given to
+their Env or OptConfig, and checks their Required options and the groups of every command of path
+against the options given to all of them, which is left until the last command of path is known.
+*/
+func (merged *commandOptions) finish(argv []string, path []*Command) bool {
+	var longindex int
+	OptGroups = nil
+	deferring = false
+
+	for _, cmd := range path {
+		OptGroups = append(OptGroups, cmd.Groups...)
+	}
+
+	for {
+		opt := finish(argv, merged.longopts, &longindex)
+
+		if opt == -1 {
+			return true
+		}
+
+		if LastError != nil {
+			return false
+		}
+
+		merged.dispatch(opt, true, longindex)
+	}
+}
+
+/* Calls OnOption of the command defining the option getoptlong.Parse returned opt for. */
+func (merged *commandOptions) dispatch(opt int, long bool, longindex int) {
+	var option *Option
+	owner := merged.shortOwners[opt]
+
+	if long {
+		option = merged.options[longindex]
+		owner = merged.owners[longindex]
+
+		if option.Flag == nil {
+			opt = option.Val
+		}
+	} else if optarrind := findShortOpt(merged.longopts, opt); optarrind != -1 {
+		option = merged.options[optarrind]
+	}
+
+	if owner != nil && owner.OnOption != nil {
+		owner.OnOption(opt, option)
+	}
+}
+
+func commandPath(path []*Command) string {
+	var names []string
+
+	for _, cmd := range path {
+		names = append(names, cmd.Name)
+	}
+
+	return strings.Join(names, " ")
+}
+
+/*
+Returns the subcommand named name, or else the only one name is a prefix of if prefix is set, along
+with the names of the subcommands name is an ambiguous prefix of.
+*/
+func findCommand(commands []*Command, name string, prefix bool) (*Command, []string) {
+	var matches []*Command
+	var names []string
+
+	for _, cmd := range commands {
+		if cmd.Name == name {
+			return cmd, nil
+		}
+
+		if prefix && name != "" && strings.HasPrefix(cmd.Name, name) {
+			matches = append(matches, cmd)
+			names = append(names, cmd.Name)
+		}
+	}
+
+	if len(matches) == 1 {
+		return matches[0], nil
+	}
+
+	return nil, names
+}
+
+func suggestCommand(path []*Command, name string, maxDistance int) []string {
+	var names []string
+	cmd := path[len(path)-1]
+
+	if maxDistance <= 0 {
+		return nil
+	}
+
+	for _, sub := range cmd.Commands {
+		names = append(names, sub.Name)
+	}
+
+	if cmd.Plugins {
+		names = append(names, Plugins(path)...)
+	}
+
+	return suggest(names, name, maxDistance)
+}
+
+/*
+Formats the help of the last command of path: its usage, summary and description, its options,
+the options it inherits from the other commands of path, its subcommands, and its plugins if
+Plugins is set.
+*/
+func FormatCommandHelp(path []*Command, width int) string {
+	var help strings.Builder
+	cmd := path[len(path)-1]
+	merged := inherit(path)
+	operands := cmd.Operands
+
+	if (len(cmd.Commands) > 0 || cmd.Plugins) && len(operands) == 0 {
+		operands = []string{"command", "[arg ...]"}
+	}
+
+	shortopts, longopts := withBuiltins(merged.shortopts, merged.longopts)
+	help.WriteString(wrapSynopsis("usage: "+commandPath(path)+" ", append(synopsis(shortopts, longopts, plain, plain), operands...), width))
+
+	if cmd.Summary != "" {
+		help.WriteString(cmd.Summary + "\n")
+	}
+
+	for _, paragraph := range strings.Split(strings.TrimSpace(cmd.Description), "\n\n") {
+		if lines := wrap(paragraph, width); len(lines) > 0 {
+			help.WriteString("\n" + strings.Join(lines, "\n") + "\n")
+		}
+	}
+
+	shortopts, longopts = withBuiltins(cmd.Shortopts, cmd.Longopts)
+
+	if options := formatHelp(shortopts, longopts, width); options != "" {
+		help.WriteString("\nOptions:\n" + options)
+	}
+
+	if options := formatHelp(merged.inheritedShortopts, merged.inheritedLongopts, width); options != "" {
+		help.WriteString("\nGlobal options:\n" + options)
+	}
+
+	if len(cmd.Commands) > 0 {
+		column := 0
+
+		for _, sub := range cmd.Commands {
+			column = max(column, helpIndent+len(sub.Name)+2)
+		}
+
+		help.WriteString("\nCommands:\n")
+
+		for _, sub := range cmd.Commands {
+			line := strings.Repeat(" ", helpIndent) + sub.Name
+
+			if sub.Summary != "" {
+				line += strings.Repeat(" ", column-len(line)) + sub.Summary
+			}
+
+			help.WriteString(line + "\n")
+		}
+	}
+
+	if cmd.Plugins {
+		plugins := Plugins(path)
+
+		if len(plugins) > 0 {
+			help.WriteString("\nPlugins:\n")
+		}
+
+		for _, plugin := range plugins {
+			help.WriteString(strings.Repeat(" ", helpIndent) + plugin + "\n")
+		}
+	}
+
+	return help.String()
+}
+
+/*
+Parses argv against the tree of commands rooted at root and returns an exit status. The options of
+each command are parsed with getoptlong.Parse and passed to OnOption of the command defining them,
+and the first operand selects a subcommand, whose options are parsed from there on along with the
+options it inherits from its ancestors. The operands of the last command, and every element after
+"--", are passed to its Run function, whose result is returned. If CommandPrefix of root is set an
+unambiguous prefix selects a subcommand, and subcommands within CommandSuggest of root, 2 unless it is
+set, of an unknown command are suggested in LastError.
+
+If the operand is not the name of a subcommand and names one of the Aliases of the command, it is
+replaced by the words of the alias with getoptlong.ExpandAlias, which are parsed in its place; errors
+in them name the alias they come from.
+
+If Plugins of the command is set, an operand that is not the name of a subcommand runs the plugin
+executable named after the command path and the operand, e.g. tool-foo for "tool foo", found on
+PATH, with the elements after the operand and the exit status of the plugin is returned. The options
+given so far are forwarded in environment variables named after root and the option, e.g.
+TOOL_OPT_DRY_RUN for --dry-run or TOOL_OPT_v for -v, holding the number of times it was given for an
+option without an argument, and its last argument otherwise.
+
+The Env and OptConfig values of options that were not given, see getoptlong.Parse, are passed to
+OnOption once the last command is known, so that options given after a command take precedence.
+
+If OptBuiltins includes BuiltinHelp, --help writes getoptlong.FormatCommandHelp of the current
+command to standard output and 0 is returned; likewise --version writes the Version of root.
+
+If an option is invalid, a required option is missing, or an operand is not a subcommand, or an
+ambiguous prefix of subcommands, of a command that has Commands, 2 is returned; LastError describes
+the error.
+*/
+func Execute(root *Command, argv []string) int {
+	var longindex int
+	path := []*Command{root}
+	progname := root.Name
+	/* Aliases expanded for the last command of path, which must not come back after its options. */
+	aliased := map[string]bool{}
+
+	if len(argv) > 0 {
+		progname = filepath.Base(argv[0])
+	}
+
+	defer func(groups []Group, origins []string, of []string) {
+		OptGroups = groups
+		withOrigins(of, origins)
+		ending = false
+		deferring = false
+	}(OptGroups, OptOrigin, originArgv)
+
+	OptInd = 1
+	OptReset = 1
+	deferring = true
+
+	for {
+		cmd := path[len(path)-1]
+		merged := inherit(path)
+		name := progname + strings.TrimPrefix(commandPath(path), root.Name)
+
+		for {
+			long := OptInd < len(argv) && nextchar == 0 && strings.HasPrefix(argv[OptInd], "--") && argv[OptInd] != "--"
+			opt := Parse(len(argv), argv, merged.shortopts, merged.longopts, &longindex)
+
+			if opt == -1 {
+				break
+			}
+
+			switch {
+			case opt == HelpRequested:
+				io.WriteString(os.Stdout, FormatCommandHelp(path, terminalWidth()))
+				return 0
+			case opt == VersionRequested:
+				fmt.Fprintln(os.Stdout, root.Version)
+				return 0
+			case LastError != nil:
+				return 2
+			default:
+				merged.dispatch(opt, long, longindex)
+			}
+		}
+
+		operands := argv[OptInd:]
+
+		if !dashdash && (len(cmd.Commands) > 0 || cmd.Plugins) && len(operands) > 0 {
+			sub, ambiguous := findCommand(cmd.Commands, operands[0], root.CommandPrefix)
+			errAt(argv, OptInd)
+
+			if _, ok := cmd.Aliases[operands[0]]; ok && (sub == nil || sub.Name != operands[0]) {
+				if aliased[operands[0]] {
+					errInvalidOpt(fmt.Sprintf("%s: alias '%s' is recursive", name, operands[0]), 0)
+
+					return 2
+				}
+
+				aliased[operands[0]] = true
+				expanded, err := ExpandAlias(argv, OptInd, cmd.Aliases)
+
+				if err != nil {
+					errInvalidOpt(fmt.Sprintf("%s: %v", name, err), 0)
+
+					return 2
+				}
+
+				argv = expanded
+
+				continue
+			}
+
+			if cmd.Plugins && (sub == nil || sub.Name != operands[0]) {
+				if executable, ok := lookPlugin(path, operands[0]); ok {
+					if !merged.finish(argv, path) {
+						return 2
+					}
+
+					return runPlugin(path, merged, executable, operands[0], operands[1:])
+				}
+			}
+
+			if len(ambiguous) > 0 {
+				errInvalidOpt(fmt.Sprintf("%s: command '%s' is ambiguous; possibilities: '%s'", name, operands[0], strings.Join(ambiguous, "' '")), 0)
+
+				return 2
+			}
+
+			if sub == nil {
+				maxDistance := root.CommandSuggest
+
+				if maxDistance == 0 {
+					maxDistance = 2
+				}
+
+				suggestions := suggestCommand(path, operands[0], maxDistance)
+				errInvalidOpt(fmt.Sprintf("%s: '%s' is not a %s command", name, operands[0], name), 0, suggestions...)
+
+				return 2
+			}
+
+			path = append(path, sub)
+			aliased = map[string]bool{}
+			OptInd++
+
+			continue
+		}
+
+		if !merged.finish(argv, path) {
+			return 2
+		}
+
+		if cmd.Run == nil && (len(cmd.Commands) > 0 || cmd.Plugins) {
+			errAt(nil, -1)
+			errInvalidOpt(fmt.Sprintf("%s: missing command", name), 0)
+
+			return 2
+		}
+
+		if cmd.Run == nil {
+			return 0
+		}
+
+		return cmd.Run(operands)
+	}
+}
@@ -0,0 +1,316 @@
+/*
+	@file      pkg/getoptlong/subcommand_test.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong_test
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/BChristieDev/getopt_long.go/pkg/getoptlong"
+)
+
+/* Builds a git style tool recording the options and operands it is given to calls. */
+func subcommandTool(calls *[]string) *getoptlong.Command {
+	onOption := func(cmd string) func(opt int, option *getoptlong.Option) {
+		return func(opt int, option *getoptlong.Option) {
+			name := fmt.Sprintf("-%c", opt)
+
+			if option != nil && option.Name != "" {
+				name = "--" + option.Name
+			}
+
+			*calls = append(*calls, fmt.Sprintf("%s %s=%s", cmd, name, getoptlong.OptArg))
+		}
+	}
+	run := func(cmd string) func(operands []string) int {
+		return func(operands []string) int {
+			*calls = append(*calls, fmt.Sprintf("%s run %s", cmd, strings.Join(operands, ",")))
+
+			return 0
+		}
+	}
+
+	return &getoptlong.Command{
+		Name:      "tool",
+		Summary:   "Manage things",
+		Shortopts: "vC:",
+		Longopts: []getoptlong.Option{
+			{Name: "verbose", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'v', Description: "be verbose"},
+			{Name: "", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'C', ArgName: "DIR", Description: "run in DIR"},
+			{Name: "dry", HasArg: getoptlong.NoArgument, Flag: nil, Val: 0, Description: "do nothing"},
+		},
+		OnOption: onOption("tool"),
+		Commands: []*getoptlong.Command{
+			{
+				Name:      "remote",
+				Summary:   "Manage remotes",
+				Shortopts: "v",
+				Longopts: []getoptlong.Option{
+					{Name: "", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'v', Description: "show URLs"},
+				},
+				OnOption: onOption("remote"),
+				Commands: []*getoptlong.Command{
+					{
+						Name:     "add",
+						Summary:  "Add a remote",
+						Longopts: []getoptlong.Option{{Name: "url", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 0, Required: true, Description: "URL of the remote"}},
+						Operands: []string{"name"},
+						OnOption: onOption("add"),
+						Run:      run("add"),
+					},
+				},
+			},
+			{
+				Name:     "log",
+				Summary:  "Show the log",
+				Operands: []string{"[path ...]"},
+				OnOption: onOption("log"),
+				Run:      run("log"),
+			},
+		},
+	}
+}
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		status   int
+		expected []string
+		err      string
+	}{
+		{
+			name:     "Global options before and after the command",
+			args:     []string{"tool", "-C", "dir", "log", "--verbose", "a", "b"},
+			expected: []string{"tool -C=dir", "tool --verbose=", "log run a,b"},
+		},
+		{
+			name:     "Options of a command shadow inherited options",
+			args:     []string{"tool", "-v", "remote", "-v", "add", "-v", "--verbose", "--url", "u", "origin"},
+			expected: []string{"tool --verbose=", "remote -v=", "remote -v=", "tool --verbose=", "add --url=u", "add run origin"},
+		},
+		{
+			name:     "Long option first",
+			args:     []string{"tool", "--dry", "log"},
+			expected: []string{"tool --dry=", "log run "},
+		},
+		{
+			name:     "Operands after the delimiter",
+			args:     []string{"tool", "log", "--", "--verbose", "-C"},
+			expected: []string{"log run --verbose,-C"},
+		},
+		{
+			name:   "No command after the delimiter",
+			args:   []string{"tool", "--", "log"},
+			status: 2,
+			err:    "tool: missing command",
+		},
+		{
+			name:   "Unknown command",
+			args:   []string{"/usr/bin/tool", "remote", "rm"},
+			status: 2,
+			err:    "tool remote: 'rm' is not a tool remote command",
+		},
+		{
+			name:     "Options of a command are not known to its parent",
+			args:     []string{"tool", "--url", "u", "remote"},
+			status:   2,
+			expected: []string{},
+			err:      "tool: unrecognized option '--url'",
+		},
+		{
+			name:     "Required option of a command",
+			args:     []string{"tool", "remote", "add", "origin"},
+			status:   2,
+			expected: []string{},
+			err:      "tool: option '--url' is required",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls []string
+
+			t.Cleanup(func() { cleanup(t) })
+
+			getoptlong.OptErr = 0
+
+			if status := getoptlong.Execute(subcommandTool(&calls), test.args); status != test.status {
+				t.Errorf("status is '%d'. Expected '%d'.\n", status, test.status)
+			}
+
+			if test.expected != nil && !slices.Equal(calls, test.expected) {
+				t.Errorf("calls are '%v'. Expected '%v'.\n", calls, test.expected)
+			}
+
+			if test.err != "" && (getoptlong.LastError == nil || getoptlong.LastError.Msg != test.err) {
+				t.Errorf("LastError is '%v'. Expected '%s'.\n", getoptlong.LastError, test.err)
+			}
+		})
+	}
+}
+
+func TestFormatCommandHelp(t *testing.T) {
+	var calls []string
+	tool := subcommandTool(&calls)
+	remote := tool.Commands[0]
+
+	t.Cleanup(func() { cleanup(t) })
+
+	getoptlong.OptBuiltins = getoptlong.BuiltinHelp
+
+	t.Run("Root", func(t *testing.T) {
+		expected := "" +
+			"usage: tool [-vh] [-C dir] [--dry] command [arg ...]\n" +
+			"Manage things\n" +
+			"\n" +
+			"Options:\n" +
+			"  -v, --verbose  be verbose\n" +
+			"  -C DIR         run in DIR\n" +
+			"  -h, --help     display this help and exit\n" +
+			"      --dry      do nothing\n" +
+			"\n" +
+			"Commands:\n" +
+			"  remote  Manage remotes\n" +
+			"  log     Show the log\n"
+
+		if help := getoptlong.FormatCommandHelp([]*getoptlong.Command{tool}, 80); help != expected {
+			t.Errorf("help is '%s'. Expected '%s'.\n", help, expected)
+		}
+	})
+
+	t.Run("Subcommand", func(t *testing.T) {
+		expected := "" +
+			"usage: tool remote add [-vh] [-C dir] --url=ARG [--verbose] [--dry] name\n" +
+			"Add a remote\n" +
+			"\n" +
+			"Options:\n" +
+			"  -h, --help     display this help and exit\n" +
+			"      --url=ARG  URL of the remote\n" +
+			"\n" +
+			"Global options:\n" +
+			"  -v             show URLs\n" +
+			"  -C DIR         run in DIR\n" +
+			"      --verbose  be verbose\n" +
+			"      --dry      do nothing\n"
+
+		if help := getoptlong.FormatCommandHelp([]*getoptlong.Command{tool, remote, remote.Commands[0]}, 80); help != expected {
+			t.Errorf("help is '%s'. Expected '%s'.\n", help, expected)
+		}
+	})
+}
+
+func TestCommandPrefix(t *testing.T) {
+	commands := func(calls *[]string) *getoptlong.Command {
+		run := func(name string) func(operands []string) int {
+			return func(operands []string) int {
+				*calls = append(*calls, name)
+
+				return 0
+			}
+		}
+
+		return &getoptlong.Command{
+			Name:          "tool",
+			CommandPrefix: true,
+			Commands: []*getoptlong.Command{
+				{Name: "stash", Run: run("stash")},
+				{Name: "status", Run: run("status")},
+				{Name: "show", Run: run("show")},
+			},
+		}
+	}
+	tests := []struct {
+		name     string
+		args     []string
+		prefix   bool
+		suggest  int
+		status   int
+		expected string
+		err      string
+	}{
+		{name: "Unambiguous prefix", args: []string{"tool", "stat"}, prefix: true, expected: "status"},
+		{name: "Exact name", args: []string{"tool", "show"}, prefix: true, expected: "show"},
+		{name: "Ambiguous prefix", args: []string{"tool", "st"}, prefix: true, status: 2, err: "tool: command 'st' is ambiguous; possibilities: 'stash' 'status'"},
+		{name: "Prefix disabled", args: []string{"tool", "stat"}, prefix: false, status: 2, err: "tool: 'stat' is not a tool command\nDid you mean 'stash' or 'status'?"},
+		{name: "Suggestion", args: []string{"tool", "stauts"}, prefix: true, status: 2, err: "tool: 'stauts' is not a tool command\nDid you mean 'status'?"},
+		{name: "Suggestions disabled", args: []string{"tool", "stauts"}, prefix: true, suggest: -1, status: 2, err: "tool: 'stauts' is not a tool command"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls []string
+			root := commands(&calls)
+			root.CommandPrefix = test.prefix
+			root.CommandSuggest = test.suggest
+
+			t.Cleanup(func() { cleanup(t) })
+
+			getoptlong.OptErr = 0
+
+			if status := getoptlong.Execute(root, test.args); status != test.status {
+				t.Errorf("status is '%d'. Expected '%d'.\n", status, test.status)
+			}
+
+			if test.expected != "" && (len(calls) != 1 || calls[0] != test.expected) {
+				t.Errorf("calls are '%v'. Expected '%s'.\n", calls, test.expected)
+			}
+
+			if test.err != "" && (getoptlong.LastError == nil || getoptlong.LastError.Error() != test.err) {
+				t.Errorf("LastError is '%v'. Expected '%s'.\n", getoptlong.LastError, test.err)
+			}
+		})
+	}
+}
+
+func TestInheritedChecks(t *testing.T) {
+	root := &getoptlong.Command{
+		Name: "tool",
+		Longopts: []getoptlong.Option{
+			{Name: "token", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 't', Required: true},
+			{Name: "json", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'j', Env: "TOOL_JSON"},
+			{Name: "yaml", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'y', Env: "TOOL_YAML"},
+		},
+		Groups: []getoptlong.Group{{Kind: getoptlong.MutuallyExclusive, Options: []string{"--json", "--yaml"}}},
+		Commands: []*getoptlong.Command{
+			{Name: "add", Run: func(operands []string) int { return 0 }},
+		},
+	}
+	tests := []struct {
+		name   string
+		args   []string
+		json   string
+		yaml   string
+		status int
+		err    string
+	}{
+		{"Required option given after the command", []string{"tool", "add", "--token", "x"}, "", "", 0, ""},
+		{"Required option missing", []string{"tool", "add"}, "", "", 2, "tool: option '--token' is required"},
+		{"Group spanning commands", []string{"tool", "--token", "x", "--json", "add", "--yaml"}, "", "", 2, "tool: options '--json' and '--yaml' are mutually exclusive"},
+		{"Fallback excluded by an option given after the command", []string{"tool", "--token", "x", "add", "--yaml"}, "1", "", 0, ""},
+		{"Fallback conflicting with a fallback", []string{"tool", "--token", "x", "add"}, "1", "1", 2, "tool: options '--json' and '--yaml' are mutually exclusive"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("TOOL_JSON", test.json)
+			t.Setenv("TOOL_YAML", test.yaml)
+			t.Cleanup(func() { cleanup(t) })
+
+			getoptlong.OptErr = 0
+
+			if status := getoptlong.Execute(root, test.args); status != test.status {
+				t.Errorf("status is '%d'. Expected '%d'.\n", status, test.status)
+			}
+
+			if test.err != "" && (getoptlong.LastError == nil || getoptlong.LastError.Msg != test.err) {
+				t.Errorf("LastError is '%v'. Expected '%s'.\n", getoptlong.LastError, test.err)
+			}
+		})
+	}
+}
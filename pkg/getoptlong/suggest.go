@@ -0,0 +1,111 @@
+/*
+	@file      pkg/getoptlong/suggest.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+import (
+	"fmt"
+	"strings"
+)
+
+func distance(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+func suggest(candidates []string, name string, maxDistance int) []string {
+	var suggestions []string
+	nearest := maxDistance + 1
+
+	for _, candidate := range candidates {
+		d := distance(candidate, name)
+
+		if d > maxDistance {
+			continue
+		}
+
+		if d < nearest {
+			nearest = d
+			suggestions = nil
+		}
+
+		if d == nearest {
+			suggestions = append(suggestions, candidate)
+		}
+	}
+
+	return suggestions
+}
+
+func suggestLongOpt(longopts []Option, name string) []string {
+	var names []string
+
+	if OptSuggest <= 0 {
+		return nil
+	}
+
+	for _, longopt := range longopts {
+		if longopt.Name == "" || longopt.Hidden {
+			continue
+		}
+
+		names = append(names, longopt.Name)
+
+		if longopt.HasArg == BooleanArgument {
+			names = append(names, OptNoPrefix+longopt.Name)
+		}
+	}
+
+	suggestions := suggest(names, name, OptSuggest)
+
+	for i := range suggestions {
+		suggestions[i] = "--" + suggestions[i]
+	}
+
+	return suggestions
+}
+
+func suggestShortOpt(shortopts string, longopts []Option, element string, nextchar int) []string {
+	var suggestions []string
+
+	if OptSuggest <= 0 {
+		return nil
+	}
+
+	/* An element like "-verbose" may be a long option given with a single dash. */
+	if nextchar == 1 && len(element) > 2 {
+		suggestions = suggestLongOpt(longopts, element[1:])
+	}
+
+	for _, e := range entries(shortopts, longopts) {
+		if e.short != 0 && e.short != int(element[nextchar]) && !e.hidden() && strings.EqualFold(string(rune(e.short)), element[nextchar:nextchar+1]) {
+			suggestions = append(suggestions, fmt.Sprintf("-%c", e.short))
+		}
+	}
+
+	return suggestions
+}
@@ -0,0 +1,131 @@
+/*
+	@file      pkg/getoptlong/suggest_test.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong_test
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/BChristieDev/getopt_long.go/pkg/getoptlong"
+)
+
+func TestSuggestions(t *testing.T) {
+	longopts := []getoptlong.Option{
+		{Name: "verbose", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'v'},
+		{Name: "version", HasArg: getoptlong.NoArgument, Flag: nil, Val: 0},
+		{Name: "color", HasArg: getoptlong.BooleanArgument, Flag: nil, Val: 0},
+		{Name: "debug", HasArg: getoptlong.NoArgument, Flag: nil, Val: 0, Hidden: true},
+	}
+
+	t.Run("Long option", func(t *testing.T) {
+		args := []string{"getoptlong_test.go", "--verbos"}
+		r, w, _ := os.Pipe()
+		oldStderr := os.Stderr
+		var opt int
+
+		t.Cleanup(func() { cleanup(t) })
+
+		os.Stderr = w
+		opt = getoptlong.Parse(len(args), args, "v", longopts, nil)
+		w.Close()
+		stderr, _ := io.ReadAll(r)
+		os.Stderr = oldStderr
+
+		if opt != '?' {
+			t.Errorf("opt is '%c'. Expected '?'.\n", opt)
+		}
+
+		expected := "getoptlong_test.go: unrecognized option '--verbos'\nDid you mean '--verbose'?\n"
+
+		if string(stderr) != expected {
+			t.Errorf("stderr is '%s'. Expected '%s'.\n", stderr, expected)
+		}
+
+		if getoptlong.LastError == nil || len(getoptlong.LastError.Suggestions) != 1 || getoptlong.LastError.Suggestions[0] != "--verbose" {
+			t.Errorf("last error is '%v'. Expected suggestion '--verbose'.\n", getoptlong.LastError)
+		}
+	})
+
+	t.Run("Near long options", func(t *testing.T) {
+		args := []string{"prog", "--vrsion"}
+
+		t.Cleanup(func() { cleanup(t) })
+
+		getoptlong.OptErr = 0
+		getoptlong.Parse(len(args), args, "v", longopts, nil)
+
+		if getoptlong.LastError.Error() != "prog: unrecognized option '--vrsion'\nDid you mean '--version'?" {
+			t.Errorf("last error is '%s'. Expected 'prog: unrecognized option '--vrsion'\nDid you mean '--version'?'.\n", getoptlong.LastError)
+		}
+
+		getoptlong.OptInd = 1
+		args = []string{"prog", "--no-colr", "--verbosity"}
+		getoptlong.Parse(len(args), args, "v", longopts, nil)
+
+		if getoptlong.LastError.Error() != "prog: unrecognized option '--no-colr'\nDid you mean '--no-color'?" {
+			t.Errorf("last error is '%s'. Expected 'prog: unrecognized option '--no-colr'\nDid you mean '--no-color'?'.\n", getoptlong.LastError)
+		}
+
+		getoptlong.Parse(len(args), args, "v", longopts, nil)
+
+		if getoptlong.LastError.Error() != "prog: unrecognized option '--verbosity'" {
+			t.Errorf("last error is '%s'. Expected 'prog: unrecognized option '--verbosity''.\n", getoptlong.LastError)
+		}
+	})
+
+	t.Run("Short option", func(t *testing.T) {
+		args := []string{"prog", "-V", "-xerbose", "-vx"}
+
+		t.Cleanup(func() { cleanup(t) })
+
+		getoptlong.OptErr = 0
+		getoptlong.Parse(len(args), args, "v", longopts, nil)
+
+		if getoptlong.LastError.Error() != "prog: invalid option -- 'V'\nDid you mean '-v'?" {
+			t.Errorf("last error is '%s'. Expected 'prog: invalid option -- 'V'\nDid you mean '-v'?'.\n", getoptlong.LastError)
+		}
+
+		getoptlong.Parse(len(args), args, "v", longopts, nil)
+
+		if getoptlong.LastError.Error() != "prog: invalid option -- 'x'\nDid you mean '--verbose'?" {
+			t.Errorf("last error is '%s'. Expected 'prog: invalid option -- 'x'\nDid you mean '--verbose'?'.\n", getoptlong.LastError)
+		}
+
+		for getoptlong.OptInd < 3 {
+			getoptlong.Parse(len(args), args, "v", longopts, nil)
+		}
+
+		if opt := getoptlong.Parse(len(args), args, "v", longopts, nil); opt != 'v' {
+			t.Errorf("opt is '%c'. Expected 'v'.\n", opt)
+		}
+
+		if getoptlong.Parse(len(args), args, "v", longopts, nil); getoptlong.LastError.Error() != "prog: invalid option -- 'x'" {
+			t.Errorf("last error is '%s'. Expected 'prog: invalid option -- 'x''.\n", getoptlong.LastError)
+		}
+
+		for getoptlong.Parse(len(args), args, "v", longopts, nil) != -1 {
+		}
+	})
+
+	t.Run("Suggestions disabled", func(t *testing.T) {
+		args := []string{"", "--verbos"}
+
+		t.Cleanup(func() { cleanup(t) })
+
+		getoptlong.OptErr = 0
+		getoptlong.OptSuggest = 0
+		getoptlong.Parse(len(args), args, "v", longopts, nil)
+
+		if len(getoptlong.LastError.Suggestions) != 0 {
+			t.Errorf("suggestions is '%v'. Expected '[]'.\n", getoptlong.LastError.Suggestions)
+		}
+
+		if getoptlong.Parse(len(args), args, "v", longopts, nil) != -1 || getoptlong.LastError != nil {
+			t.Errorf("last error is '%v'. Expected 'nil'.\n", getoptlong.LastError)
+		}
+	})
+}
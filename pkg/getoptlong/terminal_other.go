@@ -0,0 +1,16 @@
+//go:build !(darwin || freebsd || linux || netbsd || openbsd)
+
+/*
+	@file      pkg/getoptlong/terminal_other.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+import (
+	"os"
+)
+
+func ttyWidth(file *os.File) int {
+	return 0
+}
@@ -0,0 +1,28 @@
+//go:build darwin || freebsd || linux || netbsd || openbsd
+
+/*
+	@file      pkg/getoptlong/terminal_unix.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+func ttyWidth(file *os.File) int {
+	var winsize struct {
+		rows, cols, xpixel, ypixel uint16
+	}
+
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, file.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&winsize)))
+
+	if errno != 0 {
+		return 0
+	}
+
+	return int(winsize.cols)
+}
@@ -0,0 +1,95 @@
+/*
+	@file      pkg/getoptlong/usage.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+func synopsis(shortopts string, longopts []Option, literal func(string) string, placeholder func(string) string) []string {
+	var items []string
+	var flags string
+
+	for _, e := range entries(shortopts, longopts) {
+		if e.short != 0 && e.shortArg == NoArgument && !e.hidden() && (e.option == nil || !e.option.Required) {
+			flags += string(rune(e.short))
+		}
+	}
+
+	if flags != "" {
+		items = append(items, "["+literal("-"+flags)+"]")
+	}
+
+	for _, e := range entries(shortopts, longopts) {
+		var item string
+
+		if e.hidden() {
+			continue
+		}
+
+		switch {
+		case e.short != 0 && e.shortArg == RequiredArgument:
+			item = literal(fmt.Sprintf("-%c", e.short)) + " " + placeholder(strings.ToLower(e.argName()))
+		case e.short != 0 && e.shortArg == OptionalArgument:
+			item = literal(fmt.Sprintf("-%c", e.short)) + "[" + placeholder(strings.ToLower(e.argName())) + "]"
+		case e.short != 0 && e.option != nil && e.option.Required:
+			item = literal(fmt.Sprintf("-%c", e.short))
+		case e.short == 0:
+			item = e.format(literal, placeholder)
+		default:
+			continue
+		}
+
+		if e.option == nil || !e.option.Required {
+			item = "[" + item + "]"
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+func wrapSynopsis(prefix string, items []string, width int) string {
+	var usage strings.Builder
+	line := prefix
+
+	for _, item := range items {
+		if len(line) > len(prefix) && len(line)+1+len(item) > width {
+			usage.WriteString(line + "\n")
+			line = strings.Repeat(" ", len(prefix))
+		}
+
+		if len(line) > 0 && line[len(line)-1] != ' ' {
+			line += " "
+		}
+
+		line += item
+	}
+
+	usage.WriteString(line + "\n")
+
+	return usage.String()
+}
+
+/*
+Formats the BSD style synopsis "usage: progname [-ab] [-c arg] [--foo[=ARG]] operands...". Short
+options without an argument are grouped, long options with a short option are only shown by their
+short option, and options which are not Required are bracketed. Lines longer than width are
+wrapped and indented to align with the first option.
+*/
+func FormatUsage(progname string, shortopts string, longopts []Option, width int, operands ...string) string {
+	shortopts, longopts = withBuiltins(shortopts, longopts)
+
+	return wrapSynopsis("usage: "+progname+" ", append(synopsis(shortopts, longopts, plain, plain), operands...), width)
+}
+
+/* Writes getoptlong.FormatUsage to w, wrapped to the width of the terminal. */
+func PrintUsage(w io.Writer, progname string, shortopts string, longopts []Option, operands ...string) {
+	io.WriteString(w, FormatUsage(progname, shortopts, longopts, terminalWidth(), operands...))
+}
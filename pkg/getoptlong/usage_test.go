@@ -0,0 +1,56 @@
+/*
+	@file      pkg/getoptlong/usage_test.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong_test
+
+import (
+	"testing"
+
+	"github.com/BChristieDev/getopt_long.go/pkg/getoptlong"
+)
+
+func TestUsage(t *testing.T) {
+	t.Run("Synopsis", func(t *testing.T) {
+		longopts := []getoptlong.Option{
+			{Name: "foo", HasArg: getoptlong.OptionalArgument, Flag: nil, Val: 0},
+			{Name: "bar", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'b'},
+		}
+		expected := "usage: prog [-ab] [-c arg] [-d[arg]] [--foo[=ARG]] file...\n"
+
+		if usage := getoptlong.FormatUsage("prog", "abc:d::", longopts, 80, "file..."); usage != expected {
+			t.Errorf("usage is '%s'. Expected '%s'.\n", usage, expected)
+		}
+	})
+
+	t.Run("Required and hidden options", func(t *testing.T) {
+		longopts := []getoptlong.Option{
+			{Name: "output", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'o', ArgName: "FILE", Required: true},
+			{Name: "", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'q', Required: true},
+			{Name: "debug", HasArg: getoptlong.NoArgument, Flag: nil, Val: 0, Hidden: true},
+			{Name: "frob", HasArg: getoptlong.BooleanArgument, Flag: nil, Val: 0},
+		}
+		expected := "usage: prog [-v] -o file -q [--[no-]frob]\n"
+
+		if usage := getoptlong.FormatUsage("prog", "o:qv", longopts, 80); usage != expected {
+			t.Errorf("usage is '%s'. Expected '%s'.\n", usage, expected)
+		}
+	})
+
+	t.Run("Wrapping", func(t *testing.T) {
+		longopts := []getoptlong.Option{
+			{Name: "add", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 0},
+			{Name: "append", HasArg: getoptlong.NoArgument, Flag: nil, Val: 0},
+			{Name: "delete", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 0},
+		}
+		expected := "" +
+			"usage: prog [-ab] [-c arg] [-d arg]\n" +
+			"            [--add=ARG] [--append]\n" +
+			"            [--delete=ARG] file ...\n"
+
+		if usage := getoptlong.FormatUsage("prog", "abc:d:", longopts, 38, "file ..."); usage != expected {
+			t.Errorf("usage is '%s'. Expected '%s'.\n", usage, expected)
+		}
+	})
+}
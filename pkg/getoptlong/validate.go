@@ -0,0 +1,186 @@
+/*
+	@file      pkg/getoptlong/validate.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+type Group struct {
+	/* MutuallyExclusive, AllOrNone, or AtLeastOne. */
+	Kind int
+	/* Options in the group, spelled "--name" for long options and "-c" for short options. */
+	Options []string
+}
+
+const (
+	/* At most one option of the group may be given. */
+	MutuallyExclusive = 0
+	/* Either all options of the group or none of them must be given. */
+	AllOrNone = 1
+	/* At least one option of the group must be given. */
+	AtLeastOne = 2
+)
+
+var (
+	/* Groups of options checked once all options are parsed. */
+	OptGroups []Group
+	ending    = false
+	dashdash  = false
+	reported  = map[string]bool{}
+	/* Leaves the fallbacks and checks of finish to getoptlong.Execute until the last command is known. */
+	deferring = false
+)
+
+func reset() {
+	ending = false
+	dashdash = false
+	stopped = false
+	occurrences = map[string][]Occurrence{}
+	reported = map[string]bool{}
+}
+
+func checkGroup(progname string, longopts []Option, group Group) string {
+	var given, missing []string
+
+	for _, member := range group.Options {
+		if occurrence := occurrences[lookupKey(longopts, member)]; len(occurrence) > 0 {
+			given = append(given, occurrence[0].Spelling)
+		} else {
+			missing = append(missing, member)
+		}
+	}
+
+	switch group.Kind {
+	case MutuallyExclusive:
+		if len(given) > 1 {
+			return fmt.Sprintf("%s: options '%s' and '%s' are mutually exclusive", progname, given[0], given[1])
+		}
+	case AllOrNone:
+		if len(given) > 0 && len(missing) > 0 {
+			return fmt.Sprintf("%s: option '%s' requires '%s'", progname, given[0], missing[0])
+		}
+	case AtLeastOne:
+		if len(given) == 0 {
+			return fmt.Sprintf("%s: at least one of '%s' is required", progname, strings.Join(group.Options, "', '"))
+		}
+	}
+
+	return ""
+}
+
+/*
+Reports whether an option of a MutuallyExclusive group of OptGroups with the option key, other than
+it, was given in argv, which its Env or OptConfig must then not conflict with.
+*/
+func excluded(longopts []Option, key string) bool {
+	for _, group := range OptGroups {
+		keys := make([]string, len(group.Options))
+
+		for i, member := range group.Options {
+			keys[i] = lookupKey(longopts, member)
+		}
+
+		if group.Kind != MutuallyExclusive || !slices.Contains(keys, key) {
+			continue
+		}
+
+		for _, member := range keys {
+			for _, occurrence := range occurrences[member] {
+				if member != key && occurrence.Index != -1 {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func finish(argv []string, longopts []Option, indexptr *int) int {
+	progname := ""
+
+	if deferring {
+		return -1
+	}
+
+	ending = true
+
+	if len(argv) > 0 {
+		progname = filepath.Base(argv[0])
+	}
+
+	for optarrind, longopt := range longopts {
+		key := optionKey(longopts, optarrind)
+
+		if len(occurrences[key]) > 0 || reported["fallback "+key] || excluded(longopts, key) {
+			continue
+		}
+
+		reported["fallback "+key] = true
+		var value, origin string
+
+		if config, ok := OptConfig[longopt.Name]; longopt.Env != "" && os.Getenv(longopt.Env) != "" {
+			value, origin = os.Getenv(longopt.Env), "environment variable "+longopt.Env
+		} else if ok && longopt.Name != "" {
+			value, origin = config.Value, config.Origin
+		} else {
+			continue
+		}
+
+		if opt, ok := parseFallbackOpt(progname, longopts, optarrind, value, origin, indexptr); ok {
+			return opt
+		}
+	}
+
+	return check(progname, longopts)
+}
+
+/* Checks that the Required options of longopts and the groups of OptGroups are satisfied. */
+func check(progname string, longopts []Option) int {
+	errAt(nil, -1)
+
+	for optarrind, longopt := range longopts {
+		key := optionKey(longopts, optarrind)
+
+		if !longopt.Required || len(occurrences[key]) > 0 || reported[key] {
+			continue
+		}
+
+		reported[key] = true
+
+		if longopt.Name == "" {
+			OptOpt = longopt.Val
+		} else {
+			OptOpt = 0
+		}
+
+		return errInvalidOpt(fmt.Sprintf("%s: option '%s' is required", progname, key), 0)
+	}
+
+	for index, group := range OptGroups {
+		key := fmt.Sprintf("group %d", index)
+
+		if reported[key] {
+			continue
+		}
+
+		if msg := checkGroup(progname, longopts, group); msg != "" {
+			reported[key] = true
+			OptOpt = 0
+
+			return errInvalidOpt(msg, 0)
+		}
+	}
+
+	ending = false
+
+	return -1
+}
@@ -0,0 +1,270 @@
+/*
+	@file      pkg/getoptlong/validate_test.go
+	@author    Brandon Christie <bchristie.dev@gmail.com>
+*/
+
+package getoptlong_test
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/BChristieDev/getopt_long.go/pkg/getoptlong"
+)
+
+func TestRequired(t *testing.T) {
+	t.Run("Required options missing", func(t *testing.T) {
+		args := []string{"getoptlong_test.go", "--foo", "bar"}
+		r, w, _ := os.Pipe()
+		oldStderr := os.Stderr
+		longopts := []getoptlong.Option{
+			{Name: "foo", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'f'},
+			{Name: "output", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'o', Required: true},
+			{Name: "", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'q', Required: true},
+		}
+		var opt, errors int
+
+		t.Cleanup(func() { cleanup(t) })
+
+		os.Stderr = w
+
+		for {
+			opt = getoptlong.Parse(len(args), args, "o:q", longopts, nil)
+
+			if opt == -1 {
+				break
+			}
+
+			if opt == '?' {
+				errors++
+			} else if opt != 'f' {
+				t.Errorf("opt is '%c'. Expected 'f'.\n", opt)
+			}
+		}
+
+		w.Close()
+		stderr, _ := io.ReadAll(r)
+		os.Stderr = oldStderr
+
+		if errors != 2 {
+			t.Errorf("errors is '%d'. Expected '2'.\n", errors)
+		}
+
+		expected := "getoptlong_test.go: option '--output' is required\ngetoptlong_test.go: option '-q' is required\n"
+
+		if string(stderr) != expected {
+			t.Errorf("stderr is '%s'. Expected '%s'.\n", stderr, expected)
+		}
+
+		if args[getoptlong.OptInd] != "bar" {
+			t.Errorf("positional argument is '%s'. Expected 'bar'.\n", args[getoptlong.OptInd])
+		}
+	})
+
+	t.Run("Required option given by short option", func(t *testing.T) {
+		args := []string{"", "-o", "foo", "--", "bar"}
+		longopts := []getoptlong.Option{
+			{Name: "output", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'o', Required: true},
+		}
+		var opt int
+
+		t.Cleanup(func() { cleanup(t) })
+
+		for {
+			opt = getoptlong.Parse(len(args), args, "o:", longopts, nil)
+
+			if opt == -1 {
+				break
+			}
+
+			if opt != 'o' {
+				t.Errorf("opt is '%c'. Expected 'o'.\n", opt)
+			}
+		}
+
+		if args[getoptlong.OptInd] != "bar" {
+			t.Errorf("positional argument is '%s'. Expected 'bar'.\n", args[getoptlong.OptInd])
+		}
+	})
+
+	t.Run("Required option missing silent", func(t *testing.T) {
+		args := []string{""}
+		r, w, _ := os.Pipe()
+		oldStderr := os.Stderr
+		longopts := []getoptlong.Option{
+			{Name: "output", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'o', Required: true},
+		}
+		var opt int
+
+		t.Cleanup(func() { cleanup(t) })
+
+		os.Stderr = w
+		opt = getoptlong.Parse(len(args), args, ":o:", longopts, nil)
+		w.Close()
+		stderr, _ := io.ReadAll(r)
+		os.Stderr = oldStderr
+
+		if opt != '?' {
+			t.Errorf("opt is '%c'. Expected '?'.\n", opt)
+		}
+
+		if string(stderr) != "" {
+			t.Errorf("stderr is '%s'. Expected ''.\n", stderr)
+		}
+
+		if opt = getoptlong.Parse(len(args), args, ":o:", longopts, nil); opt != -1 {
+			t.Errorf("opt is '%d'. Expected '-1'.\n", opt)
+		}
+	})
+}
+
+func TestGroups(t *testing.T) {
+	t.Run("Mutually exclusive", func(t *testing.T) {
+		args := []string{"getoptlong_test.go", "-j", "--yaml"}
+		r, w, _ := os.Pipe()
+		oldStderr := os.Stderr
+		longopts := []getoptlong.Option{
+			{Name: "json", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'j'},
+			{Name: "yaml", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'y'},
+		}
+		var opt, errors int
+
+		t.Cleanup(func() { cleanup(t) })
+
+		os.Stderr = w
+		getoptlong.OptGroups = []getoptlong.Group{
+			{Kind: getoptlong.MutuallyExclusive, Options: []string{"--json", "--yaml"}},
+		}
+
+		for {
+			opt = getoptlong.Parse(len(args), args, "jy", longopts, nil)
+
+			if opt == -1 {
+				break
+			}
+
+			if opt == '?' {
+				errors++
+			}
+		}
+
+		w.Close()
+		stderr, _ := io.ReadAll(r)
+		os.Stderr = oldStderr
+
+		if errors != 1 {
+			t.Errorf("errors is '%d'. Expected '1'.\n", errors)
+		}
+
+		expected := "getoptlong_test.go: options '-j' and '--yaml' are mutually exclusive\n"
+
+		if string(stderr) != expected {
+			t.Errorf("stderr is '%s'. Expected '%s'.\n", stderr, expected)
+		}
+	})
+
+	t.Run("All or none", func(t *testing.T) {
+		args := []string{"getoptlong_test.go", "--user=foo"}
+		r, w, _ := os.Pipe()
+		oldStderr := os.Stderr
+		longopts := []getoptlong.Option{
+			{Name: "user", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'u'},
+			{Name: "password-file", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'p'},
+		}
+		var opt int
+
+		t.Cleanup(func() { cleanup(t) })
+
+		os.Stderr = w
+		getoptlong.OptGroups = []getoptlong.Group{
+			{Kind: getoptlong.AllOrNone, Options: []string{"--user", "--password-file"}},
+		}
+
+		for {
+			opt = getoptlong.Parse(len(args), args, "", longopts, nil)
+
+			if opt == -1 {
+				break
+			}
+		}
+
+		w.Close()
+		stderr, _ := io.ReadAll(r)
+		os.Stderr = oldStderr
+
+		expected := "getoptlong_test.go: option '--user' requires '--password-file'\n"
+
+		if string(stderr) != expected {
+			t.Errorf("stderr is '%s'. Expected '%s'.\n", stderr, expected)
+		}
+	})
+
+	t.Run("At least one", func(t *testing.T) {
+		args := []string{"getoptlong_test.go", "foo"}
+		r, w, _ := os.Pipe()
+		oldStderr := os.Stderr
+		longopts := []getoptlong.Option{
+			{Name: "json", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'j'},
+		}
+		var opt int
+
+		t.Cleanup(func() { cleanup(t) })
+
+		os.Stderr = w
+		getoptlong.OptGroups = []getoptlong.Group{
+			{Kind: getoptlong.AtLeastOne, Options: []string{"--json", "-y"}},
+		}
+
+		for {
+			opt = getoptlong.Parse(len(args), args, "jy", longopts, nil)
+
+			if opt == -1 {
+				break
+			}
+		}
+
+		w.Close()
+		stderr, _ := io.ReadAll(r)
+		os.Stderr = oldStderr
+
+		expected := "getoptlong_test.go: at least one of '--json', '-y' is required\n"
+
+		if string(stderr) != expected {
+			t.Errorf("stderr is '%s'. Expected '%s'.\n", stderr, expected)
+		}
+
+		if args[getoptlong.OptInd] != "foo" {
+			t.Errorf("positional argument is '%s'. Expected 'foo'.\n", args[getoptlong.OptInd])
+		}
+	})
+
+	t.Run("Satisfied groups", func(t *testing.T) {
+		args := []string{"", "-j", "-u", "foo", "-p", "bar"}
+		longopts := []getoptlong.Option{
+			{Name: "json", HasArg: getoptlong.NoArgument, Flag: nil, Val: 'j'},
+			{Name: "user", HasArg: getoptlong.RequiredArgument, Flag: nil, Val: 'u'},
+		}
+		var opt int
+
+		t.Cleanup(func() { cleanup(t) })
+
+		getoptlong.OptGroups = []getoptlong.Group{
+			{Kind: getoptlong.MutuallyExclusive, Options: []string{"--json", "-y"}},
+			{Kind: getoptlong.AllOrNone, Options: []string{"--user", "-p"}},
+			{Kind: getoptlong.AtLeastOne, Options: []string{"-j", "-y"}},
+		}
+
+		for {
+			opt = getoptlong.Parse(len(args), args, "jyu:p:", longopts, nil)
+
+			if opt == -1 {
+				break
+			}
+
+			if opt == '?' {
+				t.Errorf("opt is '?'. Expected no errors.\n")
+			}
+		}
+	})
+}